@@ -19,6 +19,7 @@ package main
 import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/apiserver-runtime/pkg/builder"
+	builderrest "sigs.k8s.io/apiserver-runtime/pkg/builder/rest"
 
 	cueserver "github.com/kubevela/pkg/cue/server"
 	apiserveroptions "github.com/kubevela/pkg/util/apiserver/options"
@@ -31,7 +32,10 @@ import (
 	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
 	grafanadashboardv1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafanadashboard/v1alpha1"
 	grafanadatasourcev1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafanadatasource/v1alpha1"
+	grafanafolderv1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafanafolder/v1alpha1"
+	grafanaorgv1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafanaorg/v1alpha1"
 	apiserver "github.com/kubevela/prism/pkg/dynamicapiserver"
+	"github.com/kubevela/prism/pkg/util/subresource"
 )
 
 func main() {
@@ -41,13 +45,23 @@ func main() {
 		ExposeLoopbackAuthorizer().
 		WithoutEtcd().
 		WithResource(&apprtv1alpha1.ApplicationResourceTracker{}).
+		WithAdditionalSchemeInstallers(apprtv1alpha1.AddToScheme).
 		WithResource(&clusterv1alpha1.Cluster{}).
+		WithResourceAndHandler(subresource.NewStatusResource(&clusterv1alpha1.Cluster{}),
+			builderrest.StaticHandlerProvider{Storage: &clusterv1alpha1.ClusterProbe{}}.Get).
+		WithAdditionalSchemeInstallers(clusterv1alpha1.AddClusterFieldLabelConversionFunc).
+		WithResource(&clusterv1alpha1.ClusterSet{}).
 		WithResource(&grafanav1alpha1.Grafana{}).
+		WithResourceAndHandler(subresource.NewStatusResource(&grafanav1alpha1.Grafana{}),
+			builderrest.StaticHandlerProvider{Storage: &grafanav1alpha1.GrafanaProbe{}}.Get).
 		WithResource(&grafanadatasourcev1alpha1.GrafanaDatasource{}).
 		WithResource(&grafanadashboardv1alpha1.GrafanaDashboard{}).
+		WithResource(&grafanaorgv1alpha1.GrafanaOrg{}).
+		WithResource(&grafanafolderv1alpha1.GrafanaFolder{}).
 		WithConfigFns(apiserveroptions.WrapConfig, singleton.InitServerConfig).
 		WithServerFns(cueserver.RegisterGenericAPIServer, singleton.InitGenericAPIServer).
 		WithPostStartHook("start-dynamic-server", apiserver.StartDefaultDynamicAPIServer).
+		WithPostStartHook("start-cluster-cache", clusterv1alpha1.StartDefaultClusterCache).
 		Build()
 	runtime.Must(err)
 	log.AddLogFlags(cmd)
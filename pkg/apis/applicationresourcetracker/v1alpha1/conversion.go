@@ -17,12 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"strings"
+	"fmt"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubevela/prism/pkg/util/delegator"
 )
 
 var (
@@ -38,18 +40,48 @@ const (
 	labelAppNamespace = "app.oam.dev/namespace"
 )
 
+// storage delegates the cluster-scoped ResourceTrackers into namespace-scoped
+// ApplicationResourceTrackers
+var storage = delegator.NewStorage(delegator.Config{
+	GroupResource:            ApplicationResourceTrackerGroupResource,
+	GroupVersionKind:         ApplicationResourceTrackerGroupVersionKind,
+	DelegateGroupVersionKind: ResourceTrackerGroupVersionKind,
+	NamespaceLabelKey:        labelAppNamespace,
+	NewFunc:                  func() runtime.Object { return &ApplicationResourceTracker{} },
+	NewListFunc:              func() runtime.Object { return &ApplicationResourceTrackerList{} },
+	FieldsFunc:               getApplicationResourceTrackerFields,
+})
+
+const (
+	// ApplicationResourceTrackerFieldType the field selector key for the tracker type
+	ApplicationResourceTrackerFieldType = "spec.type"
+)
+
+func getApplicationResourceTrackerFields(obj runtime.Object) fields.Set {
+	set := fields.Set{}
+	if appRt, ok := obj.(*ApplicationResourceTracker); ok {
+		if spec, err := appRt.getSpec(); err == nil {
+			set[ApplicationResourceTrackerFieldType] = spec.Type
+		}
+	}
+	return set
+}
+
+// convertFieldLabel allows the field selectors supported by ApplicationResourceTracker
+func convertFieldLabel(label, value string) (string, string, error) {
+	switch label {
+	case "metadata.name", "metadata.namespace", ApplicationResourceTrackerFieldType:
+		return label, value, nil
+	default:
+		return "", "", fmt.Errorf("field label not supported: %s", label)
+	}
+}
+
 // NewApplicationResourceTrackerFromResourceTracker convert KubeVela ResourceTracker to ApplicationResourceTracker
 func NewApplicationResourceTrackerFromResourceTracker(rt *unstructured.Unstructured) (*ApplicationResourceTracker, error) {
-	appRt := &ApplicationResourceTracker{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rt.Object, appRt); err != nil {
+	obj, err := storage.Convert(rt)
+	if err != nil {
 		return nil, err
 	}
-	namespace := metav1.NamespaceDefault
-	if labels := rt.GetLabels(); labels != nil && labels[labelAppNamespace] != "" {
-		namespace = labels[labelAppNamespace]
-	}
-	appRt.SetNamespace(namespace)
-	appRt.SetName(strings.TrimSuffix(rt.GetName(), "-"+namespace))
-	appRt.SetGroupVersionKind(ApplicationResourceTrackerGroupVersionKind)
-	return appRt, nil
+	return obj.(*ApplicationResourceTracker), nil
 }
@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	apirest "k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/multicluster"
+	"github.com/kubevela/pkg/util/singleton"
+	"github.com/kubevela/pkg/util/slices"
+)
+
+const (
+	// ApplicationResourceTrackerDriftSubResource the name of the subresource for
+	// checking the drift of managed resources
+	ApplicationResourceTrackerDriftSubResource = "drift"
+)
+
+var (
+	// ManagedResourceDriftTimeout the timeout for fetching each managed resource
+	ManagedResourceDriftTimeout = 10 * time.Second
+	// ManagedResourceDriftParallelism the number of managed resources fetched concurrently
+	ManagedResourceDriftParallelism = 5
+)
+
+// ManagedResourceDrift is the live state of a resource managed by the application
+type ManagedResourceDrift struct {
+	ManagedResource `json:",inline"`
+
+	// Exists indicates whether the live object is found
+	Exists bool `json:"exists"`
+	// ResourceVersion is the resourceVersion of the live object
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// TrackedHash is the hash of the manifest recorded in the tracker, empty if
+	// the manifest is not recorded
+	TrackedHash string `json:"trackedHash,omitempty"`
+	// LiveHash is the hash of the live object restricted to the fields of the
+	// tracked manifest
+	LiveHash string `json:"liveHash,omitempty"`
+	// Matched indicates whether the live object still matches the tracked
+	// manifest, unset if it cannot be decided
+	Matched *bool `json:"matched,omitempty"`
+	// Error is the error encountered while fetching the live object
+	Error string `json:"error,omitempty"`
+}
+
+// ManagedResourceDriftList list for ManagedResourceDrift
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ManagedResourceDriftList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ManagedResourceDrift `json:"items"`
+}
+
+// ApplicationResourceTrackerDrift the subresource for checking the live state
+// of the resources managed by the application. Resources in remote clusters
+// are fetched through cluster-gateway, so the caller only needs the access
+// to the ApplicationResourceTracker.
+// +kubebuilder:object:generate=false
+type ApplicationResourceTrackerDrift struct{}
+
+var _ resource.ArbitrarySubResource = &ApplicationResourceTrackerDrift{}
+var _ apirest.Connecter = &ApplicationResourceTrackerDrift{}
+
+// SubResourceName returns the name of the subresource
+func (in *ApplicationResourceTrackerDrift) SubResourceName() string {
+	return ApplicationResourceTrackerDriftSubResource
+}
+
+// New returns a new instance of the resource
+func (in *ApplicationResourceTrackerDrift) New() runtime.Object {
+	return &ApplicationResourceTrackerResourcesOptions{}
+}
+
+// Destroy .
+func (in *ApplicationResourceTrackerDrift) Destroy() {}
+
+// NewConnectOptions returns the options for filtering managed resources
+func (in *ApplicationResourceTrackerDrift) NewConnectOptions() (runtime.Object, bool, string) {
+	return &ApplicationResourceTrackerResourcesOptions{}, false, ""
+}
+
+// ConnectMethods only allows GET
+func (in *ApplicationResourceTrackerDrift) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+// Connect returns the handler that responds the live state of the managed resources
+func (in *ApplicationResourceTrackerDrift) Connect(ctx context.Context, name string, options runtime.Object, r apirest.Responder) (http.Handler, error) {
+	opts, ok := options.(*ApplicationResourceTrackerResourcesOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	obj, err := (&ApplicationResourceTracker{}).Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	spec, err := obj.(*ApplicationResourceTracker).getSpec()
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	cli, err := multicluster.NewDefaultClient(singleton.KubeConfig.Get(), client.Options{Mapper: singleton.RESTMapper.Get()})
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	mrs := slices.Filter(spec.ManagedResources, func(mr trackedManagedResource) bool {
+		res := mr.toManagedResource()
+		return (opts.Cluster == "" || opts.Cluster == res.Cluster) && (opts.Kind == "" || opts.Kind == res.Kind)
+	})
+	list := &ManagedResourceDriftList{}
+	list.SetGroupVersionKind(ManagedResourceDriftListGroupVersionKind)
+	list.Items = slices.ParMap(mrs, func(mr trackedManagedResource) ManagedResourceDrift {
+		return getManagedResourceDrift(ctx, cli, mr)
+	}, slices.Parallelism(ManagedResourceDriftParallelism))
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Object(http.StatusOK, list)
+	}), nil
+}
+
+func getManagedResourceDrift(ctx context.Context, cli client.Client, mr trackedManagedResource) ManagedResourceDrift {
+	drift := ManagedResourceDrift{ManagedResource: mr.toManagedResource()}
+	var tracked map[string]interface{}
+	if mr.Raw != nil && len(mr.Raw.Raw) > 0 {
+		if err := json.Unmarshal(mr.Raw.Raw, &tracked); err != nil {
+			drift.Error = fmt.Sprintf("invalid tracked manifest: %s", err.Error())
+			return drift
+		}
+		tracked = pruneManifest(tracked)
+		drift.TrackedHash = hashManifest(tracked)
+	}
+
+	ctx, cancel := context.WithTimeout(multicluster.WithCluster(ctx, drift.Cluster), ManagedResourceDriftTimeout)
+	defer cancel()
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(schema.GroupVersionKind(drift.GroupVersionKind))
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: drift.Namespace, Name: drift.Name}, live); err != nil {
+		if !apierrors.IsNotFound(err) {
+			drift.Error = err.Error()
+		}
+		return drift
+	}
+	drift.Exists = true
+	drift.ResourceVersion = live.GetResourceVersion()
+	if tracked != nil {
+		drift.LiveHash = hashManifest(projectManifest(tracked, live.Object))
+		matched := drift.LiveHash == drift.TrackedHash
+		drift.Matched = &matched
+	}
+	return drift
+}
+
+// pruneManifest removes the fields in the manifest that are not managed by
+// the application, such as status and metadata other than labels and annotations
+func pruneManifest(manifest map[string]interface{}) map[string]interface{} {
+	pruned := map[string]interface{}{}
+	for k, v := range manifest {
+		switch k {
+		case "status":
+		case "metadata":
+			metadata, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			m := map[string]interface{}{}
+			for _, key := range []string{"labels", "annotations"} {
+				if val, found := metadata[key]; found {
+					m[key] = val
+				}
+			}
+			pruned[k] = m
+		default:
+			pruned[k] = v
+		}
+	}
+	return pruned
+}
+
+// projectManifest returns the part of the live object that has the same
+// fields as the tracked manifest, so fields defaulted or added by others are
+// not treated as drift
+func projectManifest(tracked interface{}, live interface{}) interface{} {
+	switch t := tracked.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		projected := map[string]interface{}{}
+		for k, v := range t {
+			if val, found := l[k]; found {
+				projected[k] = projectManifest(v, val)
+			}
+		}
+		return projected
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(t) {
+			return live
+		}
+		projected := make([]interface{}, len(l))
+		for i := range t {
+			projected[i] = projectManifest(t[i], l[i])
+		}
+		return projected
+	default:
+		return live
+	}
+}
+
+func hashManifest(manifest interface{}) string {
+	// encoding/json sorts map keys, so the output is stable
+	bs, _ := json.Marshal(manifest)
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:])
+}
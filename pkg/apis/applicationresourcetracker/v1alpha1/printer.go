@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ConvertToTable convert resource to table
+func (in *ApplicationResourceTracker) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	switch obj := object.(type) {
+	case *ApplicationResourceTracker:
+		return printApplicationResourceTracker(obj), nil
+	case *ApplicationResourceTrackerList:
+		return printApplicationResourceTrackerList(obj), nil
+	default:
+		return nil, fmt.Errorf("unknown type %T", object)
+	}
+}
+
+var (
+	definitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: "the name of the application resource tracker"},
+		{Name: "Type", Type: "string", Description: "the type of the tracker, root, versioned or component-revision"},
+		{Name: "App_Generation", Type: "integer", Description: "the generation of the application tracked"},
+		{Name: "Resources", Type: "integer", Description: "the number of managed resources"},
+		{Name: "Clusters", Type: "integer", Description: "the number of clusters the managed resources are in"},
+		{Name: "Deleting", Type: "boolean", Description: "whether the tracker is being deleted"},
+		{Name: "Creation_Timestamp", Type: "dateTime", Description: "the creation timestamp of the tracker"},
+	}
+)
+
+func printApplicationResourceTracker(in *ApplicationResourceTracker) *metav1.Table {
+	return &metav1.Table{
+		ColumnDefinitions: definitions,
+		Rows:              []metav1.TableRow{printApplicationResourceTrackerRow(in)},
+	}
+}
+
+func printApplicationResourceTrackerList(in *ApplicationResourceTrackerList) *metav1.Table {
+	t := &metav1.Table{
+		ColumnDefinitions: definitions,
+	}
+	for _, appRt := range in.Items {
+		t.Rows = append(t.Rows, printApplicationResourceTrackerRow(appRt.DeepCopy()))
+	}
+	return t
+}
+
+func printApplicationResourceTrackerRow(in *ApplicationResourceTracker) metav1.TableRow {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: in},
+	}
+	var trackerType string
+	var generation int64
+	var resources, clusters int
+	// malformed spec is still printed with the metadata
+	if spec, err := in.getSpec(); err == nil {
+		trackerType, generation = spec.Type, spec.ApplicationGeneration
+	}
+	if mrs, err := in.GetManagedResources(); err == nil {
+		names := sets.NewString()
+		for _, mr := range mrs {
+			names.Insert(mr.Cluster)
+		}
+		resources, clusters = len(mrs), names.Len()
+	}
+	row.Cells = append(row.Cells,
+		in.Name,
+		trackerType,
+		generation,
+		resources,
+		clusters,
+		in.GetDeletionTimestamp() != nil,
+		in.GetCreationTimestamp())
+	return row
+}
@@ -44,8 +44,10 @@ var AddToScheme = func(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&ApplicationResourceTracker{},
 		&ApplicationResourceTrackerList{},
+		&ManagedResourceList{},
+		&ManagedResourceDriftList{},
 	)
-	return nil
+	return scheme.AddFieldLabelConversionFunc(ApplicationResourceTrackerGroupVersionKind, convertFieldLabel)
 }
 
 // GroupVersion the apiextensions v1alpha1 group version
@@ -60,4 +62,13 @@ var (
 	ApplicationResourceTrackerGroupResource = schema.GroupResource{Group: Group, Resource: ApplicationResourceTrackerResource}
 	// ApplicationResourceTrackerGroupVersionKind GroupVersionKind for ApplicationResourceTracker
 	ApplicationResourceTrackerGroupVersionKind = GroupVersion.WithKind(ApplicationResourceTrackerKind)
+
+	// ManagedResourceListKind kind name for ManagedResourceList
+	ManagedResourceListKind = "ManagedResourceList"
+	// ManagedResourceListGroupVersionKind GroupVersionKind for ManagedResourceList
+	ManagedResourceListGroupVersionKind = GroupVersion.WithKind(ManagedResourceListKind)
+	// ManagedResourceDriftListKind kind name for ManagedResourceDriftList
+	ManagedResourceDriftListKind = "ManagedResourceDriftList"
+	// ManagedResourceDriftListGroupVersionKind GroupVersionKind for ManagedResourceDriftList
+	ManagedResourceDriftListGroupVersionKind = GroupVersion.WithKind(ManagedResourceDriftListKind)
 )
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirest "k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/multicluster"
+)
+
+const (
+	// ApplicationResourceTrackerResourcesSubResource the name of the subresource for listing managed resources
+	ApplicationResourceTrackerResourcesSubResource = "resources"
+)
+
+// ManagedResource is a resource managed by the application
+type ManagedResource struct {
+	metav1.GroupVersionKind `json:",inline"`
+
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Component string `json:"component,omitempty"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+// ManagedResourceList list for ManagedResource
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ManagedResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ManagedResource `json:"items"`
+}
+
+// ApplicationResourceTrackerResources the subresource for listing the
+// resources managed by the application
+// +kubebuilder:object:generate=false
+type ApplicationResourceTrackerResources struct{}
+
+// ApplicationResourceTrackerResourcesOptions the options for filtering managed resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApplicationResourceTrackerResourcesOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Cluster only returns the resources in the cluster if set
+	Cluster string `json:"cluster,omitempty"`
+	// Kind only returns the resources of the kind if set
+	Kind string `json:"kind,omitempty"`
+}
+
+var _ resource.ArbitrarySubResource = &ApplicationResourceTrackerResources{}
+var _ apirest.Connecter = &ApplicationResourceTrackerResources{}
+var _ resource.QueryParameterObject = &ApplicationResourceTrackerResourcesOptions{}
+
+// SubResourceName returns the name of the subresource
+func (in *ApplicationResourceTrackerResources) SubResourceName() string {
+	return ApplicationResourceTrackerResourcesSubResource
+}
+
+// New returns a new instance of the resource
+func (in *ApplicationResourceTrackerResources) New() runtime.Object {
+	return &ApplicationResourceTrackerResourcesOptions{}
+}
+
+// Destroy .
+func (in *ApplicationResourceTrackerResources) Destroy() {}
+
+// NewConnectOptions returns the options for filtering managed resources
+func (in *ApplicationResourceTrackerResources) NewConnectOptions() (runtime.Object, bool, string) {
+	return &ApplicationResourceTrackerResourcesOptions{}, false, ""
+}
+
+// ConnectMethods only allows GET
+func (in *ApplicationResourceTrackerResources) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+// Connect returns the handler that responds the managed resources of the application
+func (in *ApplicationResourceTrackerResources) Connect(ctx context.Context, name string, options runtime.Object, r apirest.Responder) (http.Handler, error) {
+	opts, ok := options.(*ApplicationResourceTrackerResourcesOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	obj, err := (&ApplicationResourceTracker{}).Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	resources, err := obj.(*ApplicationResourceTracker).GetManagedResources()
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	list := &ManagedResourceList{}
+	list.SetGroupVersionKind(ManagedResourceListGroupVersionKind)
+	list.Items = []ManagedResource{}
+	for _, res := range resources {
+		if (opts.Cluster == "" || opts.Cluster == res.Cluster) && (opts.Kind == "" || opts.Kind == res.Kind) {
+			list.Items = append(list.Items, res)
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Object(http.StatusOK, list)
+	}), nil
+}
+
+// ConvertFromUrlValues parse the options from query parameters
+func (in *ApplicationResourceTrackerResourcesOptions) ConvertFromUrlValues(values *url.Values) error {
+	in.Cluster = values.Get("cluster")
+	in.Kind = values.Get("kind")
+	return nil
+}
+
+type resourceTrackerSpec struct {
+	Type                  string                   `json:"type"`
+	ApplicationGeneration int64                    `json:"applicationGeneration"`
+	ManagedResources      []trackedManagedResource `json:"managedResources"`
+}
+
+type trackedManagedResource struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Cluster    string                `json:"cluster"`
+	Namespace  string                `json:"namespace"`
+	Name       string                `json:"name"`
+	Component  string                `json:"component"`
+	Deleted    bool                  `json:"deleted"`
+	Raw        *runtime.RawExtension `json:"raw,omitempty"`
+}
+
+func (in trackedManagedResource) toManagedResource() ManagedResource {
+	gv, _ := schema.ParseGroupVersion(in.APIVersion)
+	res := ManagedResource{
+		GroupVersionKind: metav1.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: in.Kind},
+		Cluster:          in.Cluster,
+		Namespace:        in.Namespace,
+		Name:             in.Name,
+		Component:        in.Component,
+		Deleted:          in.Deleted,
+	}
+	if multicluster.IsLocal(res.Cluster) {
+		res.Cluster = multicluster.Local
+	}
+	return res
+}
+
+// GetManagedResources parse the resources managed by the application from
+// the spec. Resources in the hub cluster are reported in the local cluster.
+func (in *ApplicationResourceTracker) GetManagedResources() ([]ManagedResource, error) {
+	spec, err := in.getSpec()
+	if err != nil {
+		return nil, err
+	}
+	var resources []ManagedResource
+	for _, mr := range spec.ManagedResources {
+		resources = append(resources, mr.toManagedResource())
+	}
+	return resources, nil
+}
+
+func (in *ApplicationResourceTracker) getSpec() (*resourceTrackerSpec, error) {
+	spec := &resourceTrackerSpec{}
+	if len(in.Spec.Raw) > 0 {
+		if err := json.Unmarshal(in.Spec.Raw, spec); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
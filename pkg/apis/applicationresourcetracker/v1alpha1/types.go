@@ -21,17 +21,11 @@ import (
 
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apimachinery/pkg/watch"
 	apirest "k8s.io/apiserver/pkg/registry/rest"
 	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-
-	"github.com/kubevela/pkg/util/singleton"
 )
 
 // ApplicationResourceTracker is an extension model for ResourceTracker
@@ -53,6 +47,8 @@ type ApplicationResourceTrackerList struct {
 }
 
 var _ resource.Object = &ApplicationResourceTracker{}
+var _ resource.ObjectWithArbitrarySubResource = &ApplicationResourceTracker{}
+var _ apirest.Watcher = &ApplicationResourceTracker{}
 
 // GetObjectMeta returns the object meta reference.
 func (in *ApplicationResourceTracker) GetObjectMeta() *metav1.ObjectMeta {
@@ -94,57 +90,24 @@ func (in *ApplicationResourceTracker) ShortNames() []string {
 
 // Get finds a resource in the storage by name and returns it.
 func (in *ApplicationResourceTracker) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
-	rt := &unstructured.Unstructured{}
-	rt.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
-	ns := request.NamespaceValue(ctx)
-	if err := singleton.KubeClient.Get().Get(ctx, types.NamespacedName{Name: name + "-" + ns}, rt); err != nil {
-		return nil, err
-	}
-	return NewApplicationResourceTrackerFromResourceTracker(rt)
+	return storage.Get(ctx, name, options)
 }
 
 // List selects resources in the storage which match to the selector. 'options' can be nil.
 func (in *ApplicationResourceTracker) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
-	rts := &unstructured.UnstructuredList{}
-	rts.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
-	ns := request.NamespaceValue(ctx)
-	sel := matchingLabelsSelector{namespace: ns}
-	if options != nil {
-		sel.selector = options.LabelSelector
-	}
-	if err := singleton.KubeClient.Get().List(ctx, rts, sel); err != nil {
-		return nil, err
-	}
-	appRts := &ApplicationResourceTrackerList{}
-	for _, rt := range rts.Items {
-		appRt, err := NewApplicationResourceTrackerFromResourceTracker(rt.DeepCopy())
-		if err != nil {
-			return nil, err
-		}
-		appRts.Items = append(appRts.Items, *appRt)
-	}
-	return appRts, nil
-}
-
-// ConvertToTable convert resource to table
-func (in *ApplicationResourceTracker) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
-	return apirest.NewDefaultTableConvertor(ApplicationResourceTrackerGroupResource).ConvertToTable(ctx, object, tableOptions)
+	return storage.List(ctx, options)
 }
 
-type matchingLabelsSelector struct {
-	selector  labels.Selector
-	namespace string
+// Watch makes a watch on ApplicationResourceTrackers. The underlying
+// ResourceTrackers are watched and converted into ApplicationResourceTrackers.
+func (in *ApplicationResourceTracker) Watch(ctx context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	return storage.Watch(ctx, options)
 }
 
-// ApplyToList applies this configuration to the given list options.
-func (m matchingLabelsSelector) ApplyToList(opts *client.ListOptions) {
-	opts.LabelSelector = m.selector
-	if opts.LabelSelector == nil {
-		opts.LabelSelector = labels.NewSelector()
-	}
-	if m.namespace != "" {
-		sel := labels.SelectorFromValidatedSet(map[string]string{labelAppNamespace: m.namespace})
-		r, _ := sel.Requirements()
-		opts.LabelSelector = opts.LabelSelector.Add(r...)
+// GetArbitrarySubResources returns the list of arbitrary subresources for ApplicationResourceTracker
+func (in *ApplicationResourceTracker) GetArbitrarySubResources() []resource.ArbitrarySubResource {
+	return []resource.ArbitrarySubResource{
+		&ApplicationResourceTrackerResources{},
+		&ApplicationResourceTrackerDrift{},
 	}
 }
@@ -18,15 +18,27 @@ package v1alpha1
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
 
+	"github.com/kubevela/pkg/util/k8s"
 	"github.com/kubevela/pkg/util/singleton"
 )
 
@@ -44,7 +56,10 @@ var _ = Describe("Test ApplicationResourceTracker API", func() {
 		Ω(s.IsStorageVersion()).To(BeTrue())
 		Ω(s.NewList()).To(Equal(&ApplicationResourceTrackerList{}))
 
-		ctx := context.Background()
+		loopback.SetAuthorizer(authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+			return authorizer.DecisionAllow, "", nil
+		}))
+		ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin"})
 
 		By("Create RT")
 		createRt := func(name, ns, val string) *unstructured.Unstructured {
@@ -92,10 +107,187 @@ var _ = Describe("Test ApplicationResourceTracker API", func() {
 		Ω(ok).To(BeTrue())
 		Ω(len(appRts3.Items)).To(Equal(3))
 
+		By("Test List with field selectors")
+		listWithFields := func(ctx context.Context, sel fields.Selector) []ApplicationResourceTracker {
+			appRts, err := s.List(ctx, &metainternalversion.ListOptions{FieldSelector: sel})
+			Ω(err).To(Succeed())
+			return appRts.(*ApplicationResourceTrackerList).Items
+		}
+		Ω(listWithFields(request.WithNamespace(ctx, "default"), fields.OneTermEqualSelector("metadata.name", "app-2"))).To(HaveLen(1))
+		Ω(listWithFields(ctx, fields.OneTermEqualSelector("metadata.name", "app-1"))).To(HaveLen(2))
+		Ω(listWithFields(ctx, fields.OneTermEqualSelector(ApplicationResourceTrackerFieldType, "root"))).To(BeEmpty())
+
+		By("Test List with pagination")
+		_appRts4, err := s.List(request.WithNamespace(ctx, "default"), &metainternalversion.ListOptions{Limit: 2})
+		Ω(err).To(Succeed())
+		appRts4 := _appRts4.(*ApplicationResourceTrackerList)
+		Ω(appRts4.Items).To(HaveLen(2))
+		Ω(appRts4.Continue).ShouldNot(BeEmpty())
+		Ω(appRts4.ResourceVersion).ShouldNot(BeEmpty())
+		_appRts5, err := s.List(request.WithNamespace(ctx, "default"), &metainternalversion.ListOptions{Limit: 2, Continue: appRts4.Continue})
+		Ω(err).To(Succeed())
+		appRts5 := _appRts5.(*ApplicationResourceTrackerList)
+		Ω(appRts5.Items).To(HaveLen(1))
+		Ω(appRts5.Continue).To(BeEmpty())
+
 		_, err = s.ConvertToTable(ctx, appRt1, nil)
 		Ω(err).To(Succeed())
 		_, err = s.ConvertToTable(ctx, appRts3, nil)
 		Ω(err).To(Succeed())
 	})
 
+	It("Test ApplicationResourceTracker Resources", func() {
+		ctx := context.Background()
+		rt := &unstructured.Unstructured{}
+		rt.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
+		rt.SetName("app-res-example")
+		rt.SetLabels(map[string]string{labelAppNamespace: "example"})
+		Ω(unstructured.SetNestedField(rt.Object, int64(1), "spec", "applicationGeneration")).To(Succeed())
+		Ω(unstructured.SetNestedSlice(rt.Object, []interface{}{
+			map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "example", "name": "web", "component": "web"},
+			map[string]interface{}{"apiVersion": "v1", "kind": "Service", "cluster": "c1", "namespace": "example", "name": "web", "component": "web"},
+			map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "cluster": "c1", "namespace": "example", "name": "cfg", "deleted": true},
+		}, "spec", "managedResources")).To(Succeed())
+		Ω(singleton.KubeClient.Get().Create(ctx, rt)).To(Succeed())
+
+		s := &ApplicationResourceTrackerResources{}
+		Ω(s.SubResourceName()).To(Equal(ApplicationResourceTrackerResourcesSubResource))
+		Ω(s.ConnectMethods()).To(Equal([]string{"GET"}))
+		get := func(values url.Values) *ManagedResourceList {
+			opts, _, _ := s.NewConnectOptions()
+			Ω(opts.(*ApplicationResourceTrackerResourcesOptions).ConvertFromUrlValues(&values)).To(Succeed())
+			r := &fakeResponder{}
+			handler, err := s.Connect(request.WithNamespace(ctx, "example"), "app-res", opts, r)
+			Ω(err).To(Succeed())
+			handler.ServeHTTP(httptest.NewRecorder(), nil)
+			Ω(r.code).To(Equal(http.StatusOK))
+			return r.obj.(*ManagedResourceList)
+		}
+
+		By("Test list all managed resources")
+		resources := get(url.Values{})
+		Ω(resources.Items).To(HaveLen(3))
+		Ω(resources.Items[0]).To(Equal(ManagedResource{
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Cluster:          "local",
+			Namespace:        "example",
+			Name:             "web",
+			Component:        "web",
+		}))
+		Ω(resources.Items[2].Deleted).To(BeTrue())
+
+		By("Test filter managed resources")
+		Ω(get(url.Values{"cluster": []string{"c1"}}).Items).To(HaveLen(2))
+		Ω(get(url.Values{"cluster": []string{"c1"}, "kind": []string{"Service"}}).Items).To(HaveLen(1))
+		Ω(get(url.Values{"kind": []string{"Secret"}}).Items).To(BeEmpty())
+
+		By("Test print managed resources")
+		Ω(unstructured.SetNestedField(rt.Object, "versioned", "spec", "type")).To(Succeed())
+		Ω(singleton.KubeClient.Get().Update(ctx, rt)).To(Succeed())
+		appRt, err := (&ApplicationResourceTracker{}).Get(request.WithNamespace(ctx, "example"), "app-res", nil)
+		Ω(err).To(Succeed())
+		table, err := (&ApplicationResourceTracker{}).ConvertToTable(ctx, appRt, nil)
+		Ω(err).To(Succeed())
+		Ω(table.Rows).To(HaveLen(1))
+		Ω(table.Rows[0].Cells[:6]).To(Equal([]interface{}{"app-res", "versioned", int64(1), 3, 2, false}))
+
+		By("Test get managed resources of non-existing application")
+		opts, _, _ := s.NewConnectOptions()
+		_, err = s.Connect(request.WithNamespace(ctx, "example"), "not-exist", opts, &fakeResponder{})
+		Ω(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Test ApplicationResourceTracker Drift", func() {
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), "drift")).To(Succeed())
+		cm := &corev1.ConfigMap{Data: map[string]string{"key": "val"}}
+		cm.SetName("cfg")
+		cm.SetNamespace("drift")
+		Ω(singleton.KubeClient.Get().Create(ctx, cm)).To(Succeed())
+		manifest := func(val string) map[string]interface{} {
+			return map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "cfg", "namespace": "drift"},
+				"data":       map[string]interface{}{"key": val},
+			}
+		}
+		rt := &unstructured.Unstructured{}
+		rt.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
+		rt.SetName("app-drift-drift")
+		rt.SetLabels(map[string]string{labelAppNamespace: "drift"})
+		Ω(unstructured.SetNestedSlice(rt.Object, []interface{}{
+			map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "namespace": "drift", "name": "cfg", "raw": manifest("val")},
+			map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "namespace": "drift", "name": "cfg", "raw": manifest("changed")},
+			map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "namespace": "drift", "name": "not-exist"},
+		}, "spec", "managedResources")).To(Succeed())
+		Ω(singleton.KubeClient.Get().Create(ctx, rt)).To(Succeed())
+
+		s := &ApplicationResourceTrackerDrift{}
+		Ω(s.SubResourceName()).To(Equal(ApplicationResourceTrackerDriftSubResource))
+		opts, _, _ := s.NewConnectOptions()
+		r := &fakeResponder{}
+		handler, err := s.Connect(request.WithNamespace(ctx, "drift"), "app-drift", opts, r)
+		Ω(err).To(Succeed())
+		handler.ServeHTTP(httptest.NewRecorder(), nil)
+		Ω(r.code).To(Equal(http.StatusOK))
+		drifts := r.obj.(*ManagedResourceDriftList)
+		Ω(drifts.Items).To(HaveLen(3))
+		Ω(drifts.Items[0].Exists).To(BeTrue())
+		Ω(drifts.Items[0].ResourceVersion).To(Equal(cm.GetResourceVersion()))
+		Ω(*drifts.Items[0].Matched).To(BeTrue())
+		Ω(*drifts.Items[1].Matched).To(BeFalse())
+		Ω(drifts.Items[2].Exists).To(BeFalse())
+		Ω(drifts.Items[2].Matched).To(BeNil())
+		Ω(drifts.Items[2].Error).To(BeEmpty())
+	})
+
+	It("Test ApplicationResourceTracker Watch", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s := &ApplicationResourceTracker{}
+		w, err := s.Watch(request.WithNamespace(ctx, "watch"), nil)
+		Ω(err).To(Succeed())
+		defer w.Stop()
+
+		createRt := func(name, ns string) *unstructured.Unstructured {
+			rt := &unstructured.Unstructured{}
+			rt.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
+			rt.SetName(name + "-" + ns)
+			rt.SetLabels(map[string]string{labelAppNamespace: ns})
+			Ω(singleton.KubeClient.Get().Create(ctx, rt)).To(Succeed())
+			return rt
+		}
+		createRt("app-watch", "other")
+		rt := createRt("app-watch", "watch")
+
+		By("Test receive converted events in the namespace")
+		var event watch.Event
+		Eventually(w.ResultChan()).Should(Receive(&event))
+		Ω(event.Type).To(Equal(watch.Added))
+		appRt, ok := event.Object.(*ApplicationResourceTracker)
+		Ω(ok).To(BeTrue())
+		Ω(appRt.GetName()).To(Equal("app-watch"))
+		Ω(appRt.GetNamespace()).To(Equal("watch"))
+
+		Ω(singleton.KubeClient.Get().Delete(ctx, rt)).To(Succeed())
+		Eventually(w.ResultChan()).Should(Receive(&event))
+		Ω(event.Type).To(Equal(watch.Deleted))
+		Ω(event.Object.(*ApplicationResourceTracker).GetName()).To(Equal("app-watch"))
+	})
+
 })
+
+type fakeResponder struct {
+	code int
+	obj  runtime.Object
+	err  error
+}
+
+func (in *fakeResponder) Object(statusCode int, obj runtime.Object) {
+	in.code, in.obj = statusCode, obj
+}
+
+func (in *fakeResponder) Error(err error) {
+	in.err = err
+}
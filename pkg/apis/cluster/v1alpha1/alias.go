@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+)
+
+const (
+	// ClusterAliasSubResource the name of the subresource for renaming cluster alias
+	ClusterAliasSubResource = "alias"
+)
+
+// ClusterAlias the subresource for changing the alias of cluster. The alias
+// must be unique across all clusters.
+// +kubebuilder:object:generate=false
+type ClusterAlias struct{}
+
+var _ resource.ArbitrarySubResource = &ClusterAlias{}
+var _ rest.Getter = &ClusterAlias{}
+var _ rest.Updater = &ClusterAlias{}
+
+// SubResourceName returns the name of the subresource
+func (in *ClusterAlias) SubResourceName() string {
+	return ClusterAliasSubResource
+}
+
+// New returns a new instance of the resource
+func (in *ClusterAlias) New() runtime.Object {
+	return &Cluster{}
+}
+
+// Destroy .
+func (in *ClusterAlias) Destroy() {}
+
+// Get finds the cluster by name or alias and returns it.
+func (in *ClusterAlias) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return NewDefaultClusterClient().Get(ctx, name)
+}
+
+// Update changes the alias of the cluster. Other fields are ignored. The
+// cluster is found by the exact name rather than alias.
+func (in *ClusterAlias) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cli := NewDefaultClusterClient()
+	existing, err := cli.GetByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, existing.DeepCopy())
+	if err != nil {
+		return nil, false, err
+	}
+	updated, ok := obj.(*Cluster)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a Cluster: %#v", obj))
+	}
+	cluster := existing.DeepCopy()
+	cluster.SetResourceVersion(updated.GetResourceVersion())
+	cluster.Spec.Alias = updated.Spec.Alias
+	if updateValidation != nil {
+		if err = updateValidation(ctx, cluster, existing); err != nil {
+			return nil, false, err
+		}
+	}
+	if err = cli.Update(ctx, cluster); err != nil {
+		return nil, false, err
+	}
+	obj, err = cli.GetByName(ctx, cluster.GetName())
+	return obj, false, err
+}
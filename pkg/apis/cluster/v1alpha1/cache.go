@@ -0,0 +1,456 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	clustergatewaycommon "github.com/oam-dev/cluster-gateway/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	clientrest "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/strings/slices"
+	ocmclusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	ocmclusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/apiserver"
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// DefaultClusterCache the cluster cache started with the server. It is nil
+// if the cluster cache is not enabled.
+var DefaultClusterCache = singleton.NewSingleton[*ClusterCache](nil)
+
+// StartDefaultClusterCache starts the cluster cache if enabled, it blocks
+// until the cache is synced
+func StartDefaultClusterCache(ctx server.PostStartHookContext) error {
+	if !EnableClusterCache {
+		return nil
+	}
+	c := NewClusterCache()
+	if err := c.Start(ctx.StopCh, singleton.KubeConfig.Get()); err != nil {
+		return err
+	}
+	DefaultClusterCache.Set(c)
+	return nil
+}
+
+// ClusterCache maintains the clusters merged from cluster secrets and OCM
+// ManagedClusters in memory, indexed by name, alias and labels. It is fed
+// by informers and by the writes of the cache-backed cluster client.
+// +kubebuilder:object:generate=false
+type ClusterCache struct {
+	mu              sync.RWMutex
+	secrets         map[string]*corev1.Secret
+	managedClusters map[string]*ocmclusterv1.ManagedCluster
+	clusters        map[string]*Cluster
+	names           []string
+	aliases         map[string]sets.String
+	labels          map[string]sets.String
+	resourceVersion uint64
+}
+
+// NewClusterCache create an empty cluster cache
+func NewClusterCache() *ClusterCache {
+	return &ClusterCache{
+		secrets:         map[string]*corev1.Secret{},
+		managedClusters: map[string]*ocmclusterv1.ManagedCluster{},
+		clusters:        map[string]*Cluster{},
+		aliases:         map[string]sets.String{},
+		labels:          map[string]sets.String{},
+	}
+}
+
+// Start runs the informers of cluster secrets and OCM ManagedClusters and
+// waits for them to be synced. ManagedClusters are skipped if OCM is not
+// installed.
+func (c *ClusterCache) Start(stopCh <-chan struct{}, cfg *clientrest.Config) error {
+	cli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.upsert,
+		UpdateFunc: func(_, obj interface{}) { c.upsert(obj) },
+		DeleteFunc: c.delete,
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(cli, 0,
+		informers.WithNamespace(StorageNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = clustergatewaycommon.LabelKeyClusterCredentialType
+		}))
+	secretInformer := factory.Core().V1().Secrets().Informer()
+	if _, err = secretInformer.AddEventHandler(handler); err != nil {
+		return err
+	}
+	synced := []cache.InformerSynced{secretInformer.HasSynced}
+	factory.Start(stopCh)
+
+	if _, err = cli.Discovery().ServerResourcesForGroupVersion(ocmclusterv1.GroupVersion.String()); err == nil {
+		ocmcli, err := ocmclusterclientset.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		ocmfactory := ocmclusterinformers.NewSharedInformerFactory(ocmcli, 0)
+		managedClusterInformer := ocmfactory.Cluster().V1().ManagedClusters().Informer()
+		if _, err = managedClusterInformer.AddEventHandler(handler); err != nil {
+			return err
+		}
+		synced = append(synced, managedClusterInformer.HasSynced)
+		ocmfactory.Start(stopCh)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	} else {
+		klog.Infof("OCM ManagedCluster is not installed, skip caching ManagedClusters")
+	}
+
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return fmt.Errorf("failed to sync cluster cache")
+	}
+	return nil
+}
+
+// Get finds the cluster by name, or by alias if no cluster has the name
+func (c *ClusterCache) Get(name string) (*Cluster, error) {
+	if name == ClusterLocalName {
+		return NewLocalCluster(), nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cluster, found := c.clusters[name]
+	if !found {
+		if names := c.aliases[name]; names.Len() == 1 {
+			cluster, found = c.clusters[names.List()[0]]
+		}
+	}
+	if !found {
+		return nil, apierrors.NewNotFound(ClusterGroupResource, name)
+	}
+	return cluster.refresh(), nil
+}
+
+// GetByName returns the cluster with the exact name
+func (c *ClusterCache) GetByName(name string) (*Cluster, error) {
+	if name == ClusterLocalName {
+		return NewLocalCluster(), nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cluster, found := c.clusters[name]
+	if !found {
+		return nil, apierrors.NewNotFound(ClusterGroupResource, name)
+	}
+	return cluster.refresh(), nil
+}
+
+// List returns the clusters matching the options in the same order as the
+// ClusterClient reading from the kube-apiserver directly
+func (c *ClusterCache) List(options ...client.ListOption) (*ClusterList, error) {
+	opts := apiserver.NewListOptions(options...)
+	token, err := decodeClusterListContinue(opts.Continue)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	sel := clusterSelector{Selector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	clusters := &ClusterList{}
+	if c.resourceVersion > 0 {
+		clusters.ResourceVersion = strconv.FormatUint(c.resourceVersion, 10)
+	}
+	if local := NewLocalCluster(); opts.Continue == "" && sel.Matches(local) {
+		clusters.Items = append(clusters.Items, *local)
+	}
+	names := c.candidates(sel.Selector)
+	after := token.After
+	for i := sort.SearchStrings(names, after); i < len(names); i++ {
+		if names[i] == token.After {
+			continue
+		}
+		if opts.Limit > 0 && int64(len(clusters.Items)) >= opts.Limit {
+			next := &clusterListContinue{After: after}
+			if clusters.Continue, err = next.encode(); err != nil {
+				return nil, err
+			}
+			return clusters, nil
+		}
+		after = names[i]
+		if cluster := c.clusters[names[i]].refresh(); sel.Matches(cluster) {
+			clusters.Items = append(clusters.Items, *cluster)
+		}
+	}
+	return clusters, nil
+}
+
+// candidates returns the sorted names of clusters that could match the label
+// selector. Equality requirements on non-synthetic labels are resolved with
+// the label index, other requirements are left to the caller.
+func (c *ClusterCache) candidates(sel labels.Selector) []string {
+	if sel == nil {
+		return c.names
+	}
+	var matched sets.String
+	requirements, _ := sel.Requirements()
+	for _, r := range requirements {
+		if slices.Contains(syntheticClusterLabels, r.Key()) {
+			continue
+		}
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			names := sets.NewString()
+			for _, v := range r.Values().List() {
+				names = names.Union(c.labels[r.Key()+"="+v])
+			}
+			if matched == nil {
+				matched = names
+			} else {
+				matched = matched.Intersection(names)
+			}
+		}
+	}
+	if matched == nil {
+		return c.names
+	}
+	return matched.List()
+}
+
+func (c *ClusterCache) upsert(obj interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		if o.GetNamespace() != StorageNamespace {
+			return
+		}
+		if _, found := o.GetLabels()[clustergatewaycommon.LabelKeyClusterCredentialType]; !found {
+			c.remove(o)
+			return
+		}
+		if existing, found := c.secrets[o.GetName()]; found && isStale(existing, o) {
+			return
+		}
+		c.secrets[o.GetName()] = o.DeepCopy()
+	case *ocmclusterv1.ManagedCluster:
+		if existing, found := c.managedClusters[o.GetName()]; found && isStale(existing, o) {
+			return
+		}
+		c.managedClusters[o.GetName()] = o.DeepCopy()
+	default:
+		return
+	}
+	c.observe(obj.(client.Object))
+	c.index(obj.(client.Object).GetName())
+}
+
+func (c *ClusterCache) delete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if o, ok := obj.(client.Object); ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.remove(o)
+	}
+}
+
+func (c *ClusterCache) remove(obj client.Object) {
+	switch obj.(type) {
+	case *corev1.Secret:
+		if obj.GetNamespace() != StorageNamespace {
+			return
+		}
+		delete(c.secrets, obj.GetName())
+	case *ocmclusterv1.ManagedCluster:
+		delete(c.managedClusters, obj.GetName())
+	default:
+		return
+	}
+	c.observe(obj)
+	c.index(obj.GetName())
+}
+
+// observe records the latest resource version
+func (c *ClusterCache) observe(obj client.Object) {
+	if rv, err := strconv.ParseUint(obj.GetResourceVersion(), 10, 64); err == nil && rv > c.resourceVersion {
+		c.resourceVersion = rv
+	}
+}
+
+// index rebuilds the cluster of the given name from the cached objects and
+// updates the indices. The cluster secret takes precedence if both exist.
+func (c *ClusterCache) index(name string) {
+	if existing, found := c.clusters[name]; found {
+		c.unindex(existing)
+	}
+	var secrets []corev1.Secret
+	var managedClusters []ocmclusterv1.ManagedCluster
+	if secret, found := c.secrets[name]; found {
+		secrets = append(secrets, *secret)
+	}
+	if managedCluster, found := c.managedClusters[name]; found {
+		managedClusters = append(managedClusters, *managedCluster)
+	}
+	i := sort.SearchStrings(c.names, name)
+	exists := i < len(c.names) && c.names[i] == name
+	clusters := mergeClusters(secrets, managedClusters)
+	if len(clusters) == 0 {
+		if exists {
+			c.names = append(c.names[:i], c.names[i+1:]...)
+		}
+		return
+	}
+	cluster := &clusters[0]
+	c.clusters[name] = cluster
+	if !exists {
+		c.names = append(c.names, "")
+		copy(c.names[i+1:], c.names[i:])
+		c.names[i] = name
+	}
+	if cluster.Spec.Alias != "" {
+		if c.aliases[cluster.Spec.Alias] == nil {
+			c.aliases[cluster.Spec.Alias] = sets.NewString()
+		}
+		c.aliases[cluster.Spec.Alias].Insert(name)
+	}
+	for k, v := range cluster.GetLabels() {
+		if c.labels[k+"="+v] == nil {
+			c.labels[k+"="+v] = sets.NewString()
+		}
+		c.labels[k+"="+v].Insert(name)
+	}
+}
+
+func (c *ClusterCache) unindex(cluster *Cluster) {
+	name := cluster.GetName()
+	delete(c.clusters, name)
+	if names, found := c.aliases[cluster.Spec.Alias]; found {
+		if names.Delete(name); names.Len() == 0 {
+			delete(c.aliases, cluster.Spec.Alias)
+		}
+	}
+	for k, v := range cluster.GetLabels() {
+		if names, found := c.labels[k+"="+v]; found {
+			if names.Delete(name); names.Len() == 0 {
+				delete(c.labels, k+"="+v)
+			}
+		}
+	}
+}
+
+// isStale checks if the incoming object is older than the cached one, which
+// happens when the informer event arrives after the write of the client
+func isStale(existing, incoming client.Object) bool {
+	existingRV, err := strconv.ParseUint(existing.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return false
+	}
+	incomingRV, err := strconv.ParseUint(incoming.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return false
+	}
+	return incomingRV < existingRV
+}
+
+// refresh returns a copy of the cached cluster with the time-dependent
+// certificate expiry recomputed
+func (in *Cluster) refresh() *Cluster {
+	cluster := in.DeepCopy()
+	if cluster.Status.CertNotAfter != nil {
+		cluster.setCertificateExpiry(cluster.Status.CertNotAfter.Time)
+	}
+	return cluster
+}
+
+type cachedClusterClient struct {
+	*clusterClient
+	cache *ClusterCache
+}
+
+// NewCachedClusterClient create a client for accessing cluster, which reads
+// clusters from the cache and writes through the given client. Written
+// objects are fed into the cache immediately.
+func NewCachedClusterClient(cli client.Client, c *ClusterCache) ClusterClient {
+	return &cachedClusterClient{
+		clusterClient: &clusterClient{Client: &clusterCacheWriter{Client: cli, cache: c}},
+		cache:         c,
+	}
+}
+
+// Get finds the cluster by name, or by alias if no cluster has the name
+func (c *cachedClusterClient) Get(_ context.Context, name string) (*Cluster, error) {
+	return c.cache.Get(name)
+}
+
+// GetByName finds the cluster by the exact name
+func (c *cachedClusterClient) GetByName(_ context.Context, name string) (*Cluster, error) {
+	return c.cache.GetByName(name)
+}
+
+// List returns the clusters in the cache
+func (c *cachedClusterClient) List(_ context.Context, options ...client.ListOption) (*ClusterList, error) {
+	return c.cache.List(options...)
+}
+
+// clusterCacheWriter feeds the objects written to the kube-apiserver into
+// the cluster cache, so that the written clusters can be read back at once
+type clusterCacheWriter struct {
+	client.Client
+	cache *ClusterCache
+}
+
+// Create creates the object and feeds it into the cache
+func (w *clusterCacheWriter) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := w.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.cache.upsert(obj)
+	return nil
+}
+
+// Update updates the object and feeds it into the cache
+func (w *clusterCacheWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.cache.upsert(obj)
+	return nil
+}
+
+// Delete deletes the object and removes it from the cache
+func (w *clusterCacheWriter) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := w.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	w.cache.delete(obj)
+	return nil
+}
@@ -18,12 +18,20 @@ package v1alpha1
 
 import (
 	"context"
-	"sort"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	clustergatewayv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
 	clustergatewaycommon "github.com/oam-dev/cluster-gateway/pkg/common"
+	clustergatewayconfig "github.com/oam-dev/cluster-gateway/pkg/config"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -34,13 +42,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubevela/pkg/util/apiserver"
+	"github.com/kubevela/pkg/util/singleton"
 )
 
-// ClusterClient client for reading cluster information
+// ClusterClient client for operating clusters
 // +kubebuilder:object:generate=false
 type ClusterClient interface {
 	Get(ctx context.Context, name string) (*Cluster, error)
+	GetByName(ctx context.Context, name string) (*Cluster, error)
 	List(ctx context.Context, options ...client.ListOption) (*ClusterList, error)
+	Create(ctx context.Context, cluster *Cluster) error
+	Update(ctx context.Context, cluster *Cluster) error
+	Delete(ctx context.Context, cluster *Cluster) error
+	RotateCredential(ctx context.Context, cluster *Cluster) error
 }
 
 type clusterClient struct {
@@ -52,7 +66,34 @@ func NewClusterClient(cli client.Client) ClusterClient {
 	return &clusterClient{Client: cli}
 }
 
+// NewDefaultClusterClient create the client used by the cluster apis. Clusters
+// are read from the DefaultClusterCache if it is started.
+func NewDefaultClusterClient() ClusterClient {
+	if c := DefaultClusterCache.Get(); c != nil {
+		return NewCachedClusterClient(singleton.KubeClient.Get(), c)
+	}
+	return NewClusterClient(singleton.KubeClient.Get())
+}
+
+// Get finds the cluster by name, or by alias if no cluster has the name
 func (c *clusterClient) Get(ctx context.Context, name string) (*Cluster, error) {
+	cluster, err := c.GetByName(ctx, name)
+	if !apierrors.IsNotFound(err) {
+		return cluster, err
+	}
+	clusters, _err := c.List(ctx, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(ClusterFieldAlias, name)})
+	if _err != nil {
+		return nil, _err
+	}
+	if len(clusters.Items) != 1 {
+		return nil, err
+	}
+	return &clusters.Items[0], nil
+}
+
+// GetByName finds the cluster by the exact name. Writes must find clusters
+// through it, so that an alias never resolves into another cluster.
+func (c *clusterClient) GetByName(ctx context.Context, name string) (*Cluster, error) {
 	if name == ClusterLocalName {
 		return NewLocalCluster(), nil
 	}
@@ -95,69 +136,268 @@ func (c *clusterClient) Get(ctx context.Context, name string) (*Cluster, error)
 
 func (c *clusterClient) List(ctx context.Context, options ...client.ListOption) (*ClusterList, error) {
 	opts := apiserver.NewListOptions(options...)
-	local := NewLocalCluster()
-	clusters := &ClusterList{Items: []Cluster{*local}}
-
-	secrets := &corev1.SecretList{}
-	err := c.Client.List(ctx, secrets, clusterSelector{Selector: opts.LabelSelector, RequireCredentialType: true})
+	token, err := decodeClusterListContinue(opts.Continue)
 	if err != nil {
-		return nil, err
+		return nil, apierrors.NewBadRequest(err.Error())
 	}
-	for _, secret := range secrets.Items {
-		if cluster, err := NewClusterFromSecret(secret.DeepCopy()); err == nil {
-			clusters.Items = append(clusters.Items, *cluster)
+	sel := clusterSelector{Selector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+	secrets := c.newSecretPager(token.After, token.Secrets, opts.Limit, sel)
+	managedClusters := c.newManagedClusterPager(token.After, token.ManagedClusters, opts.Limit, sel)
+
+	clusters := &ClusterList{}
+	if local := NewLocalCluster(); opts.Continue == "" && sel.Matches(local) {
+		clusters.Items = append(clusters.Items, *local)
+	}
+	// merge sources in name order, the cluster secret takes precedence if both exist
+	for opts.Limit <= 0 || int64(len(clusters.Items)) < opts.Limit {
+		secret, err := secrets.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		managedCluster, err := managedClusters.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil && managedCluster == nil {
+			break
+		}
+		var _secrets []corev1.Secret
+		var _managedClusters []ocmclusterv1.ManagedCluster
+		if secret != nil && (managedCluster == nil || secret.GetName() <= managedCluster.GetName()) {
+			_secrets = append(_secrets, *secret.(*corev1.Secret))
+			secrets.pop()
+		}
+		if managedCluster != nil && (secret == nil || managedCluster.GetName() <= secret.GetName()) {
+			_managedClusters = append(_managedClusters, *managedCluster.(*ocmclusterv1.ManagedCluster))
+			managedClusters.pop()
+		}
+		for _, cluster := range mergeClusters(_secrets, _managedClusters) {
+			if sel.Matches(&cluster) {
+				clusters.Items = append(clusters.Items, cluster)
+			}
 		}
 	}
+	clusters.ResourceVersion = maxResourceVersion(secrets.resourceVersion, managedClusters.resourceVersion)
 
-	managedClusters := &ocmclusterv1.ManagedClusterList{}
-	err = c.Client.List(ctx, managedClusters, clusterSelector{Selector: opts.LabelSelector, RequireCredentialType: false, IgnoreNamespace: true})
-	if err != nil && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+	// set continue token if there are remaining clusters
+	if opts.Limit > 0 {
+		secret, err := secrets.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		managedCluster, err := managedClusters.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if secret != nil || managedCluster != nil {
+			next := &clusterListContinue{After: secrets.after, Secrets: secrets.cursor, ManagedClusters: managedClusters.cursor}
+			if managedClusters.after > next.After {
+				next.After = managedClusters.after
+			}
+			if clusters.Continue, err = next.encode(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return clusters, nil
+}
+
+func (c *clusterClient) Create(ctx context.Context, cluster *Cluster) error {
+	if cluster.GetName() == ClusterLocalName {
+		return apierrors.NewAlreadyExists(ClusterGroupResource, ClusterLocalName)
+	}
+	if _, err := c.GetByName(ctx, cluster.GetName()); err == nil {
+		return apierrors.NewAlreadyExists(ClusterGroupResource, cluster.GetName())
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := c.validateAlias(ctx, cluster); err != nil {
+		return err
+	}
+	secret, err := cluster.ToSecret()
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return c.Client.Create(ctx, secret)
+}
+
+// getBackendObject returns the underlying secret or ManagedCluster of the cluster
+func (c *clusterClient) getBackendObject(ctx context.Context, cluster *Cluster) (client.Object, error) {
+	if cluster.GetName() == ClusterLocalName {
+		return nil, apierrors.NewBadRequest("cannot modify the local cluster")
+	}
+	var obj client.Object = &corev1.Secret{}
+	if cluster.Spec.CredentialType == CredentialTypeOCMManagedCluster {
+		obj = &ocmclusterv1.ManagedCluster{}
+	}
+	key := apitypes.NamespacedName{Name: cluster.GetName(), Namespace: StorageNamespace}
+	if err := c.Client.Get(ctx, key, obj); err != nil {
 		return nil, err
 	}
-	for _, managedCluster := range managedClusters.Items {
-		if !clusters.HasCluster(managedCluster.Name) {
-			if cluster, err := NewClusterFromManagedCluster(managedCluster.DeepCopy()); err == nil {
-				clusters.Items = append(clusters.Items, *cluster)
-			}
+	return obj, nil
+}
+
+// validateAlias ensures the alias of the cluster is not used by other clusters
+// as alias or name, so that clusters can be resolved by alias unambiguously
+func (c *clusterClient) validateAlias(ctx context.Context, cluster *Cluster) error {
+	if cluster.Spec.Alias == "" || cluster.Spec.Alias == cluster.GetName() {
+		return nil
+	}
+	clusters, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range clusters.Items {
+		if item.GetName() != cluster.GetName() && (item.Spec.Alias == cluster.Spec.Alias || item.GetName() == cluster.Spec.Alias) {
+			return apierrors.NewConflict(ClusterGroupResource, cluster.GetName(),
+				fmt.Errorf("alias %s is already used by cluster %s", cluster.Spec.Alias, item.GetName()))
 		}
 	}
+	return nil
+}
 
-	// filter clusters
-	var items []Cluster
-	for _, cluster := range clusters.Items {
-		if opts.LabelSelector == nil || opts.LabelSelector.Matches(labels.Set(cluster.GetLabels())) {
-			items = append(items, cluster)
+func (c *clusterClient) Update(ctx context.Context, cluster *Cluster) error {
+	obj, err := c.getBackendObject(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	if err = c.validateAlias(ctx, cluster); err != nil {
+		return err
+	}
+	if cluster.GetResourceVersion() != "" {
+		obj.SetResourceVersion(cluster.GetResourceVersion())
+	}
+	_labels := extractLabels(cluster.GetLabels())
+	for k, v := range obj.GetLabels() {
+		if strings.HasPrefix(k, clustergatewayconfig.MetaApiGroupName) {
+			_labels[k] = v
 		}
 	}
-	clusters.Items = items
+	obj.SetLabels(_labels)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if cluster.Spec.Alias == "" {
+		delete(annotations, AnnotationClusterAlias)
+	} else {
+		annotations[AnnotationClusterAlias] = cluster.Spec.Alias
+	}
+	obj.SetAnnotations(annotations)
+	return c.Client.Update(ctx, obj)
+}
+
+func (c *clusterClient) Delete(ctx context.Context, cluster *Cluster) error {
+	if cluster.Spec.CredentialType == CredentialTypeOCMManagedCluster {
+		return apierrors.NewBadRequest(fmt.Sprintf("cluster %s is managed by OCM, detach it through OCM instead", cluster.GetName()))
+	}
+	obj, err := c.getBackendObject(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	trackers, err := c.getReferringResourceTrackers(ctx, cluster.GetName())
+	if err != nil {
+		return err
+	}
+	if len(trackers) > 0 {
+		return apierrors.NewForbidden(ClusterGroupResource, cluster.GetName(),
+			fmt.Errorf("cluster is still used by ResourceTrackers %s", strings.Join(trackers, ", ")))
+	}
+	return c.Client.Delete(ctx, obj)
+}
 
-	// sort clusters
-	sort.Slice(clusters.Items, func(i, j int) bool {
-		if clusters.Items[i].Name == ClusterLocalName {
-			return true
-		} else if clusters.Items[j].Name == ClusterLocalName {
-			return false
-		} else {
-			return clusters.Items[i].CreationTimestamp.After(clusters.Items[j].CreationTimestamp.Time)
+// RotateCredential validates spec.credential of the cluster against its
+// endpoint and replaces the credential in the cluster secret
+func (c *clusterClient) RotateCredential(ctx context.Context, cluster *Cluster) error {
+	if cluster.Spec.CredentialType == CredentialTypeOCMManagedCluster {
+		return apierrors.NewBadRequest(fmt.Sprintf("cluster %s is managed by OCM, its credential cannot be rotated", cluster.GetName()))
+	}
+	if cluster.Spec.Credential == nil {
+		return apierrors.NewBadRequest("no credential provided")
+	}
+	obj, err := c.getBackendObject(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	secret := obj.(*corev1.Secret)
+	if endpointType, found := secret.GetLabels()[clustergatewaycommon.LabelKeyClusterEndpointType]; found && endpointType != string(clustergatewayv1alpha1.ClusterEndpointTypeConst) {
+		return apierrors.NewBadRequest(fmt.Sprintf("cannot validate credential for cluster with endpoint type %s", endpointType))
+	}
+	cred := cluster.Spec.Credential.DeepCopy()
+	if len(cred.CAData) == 0 && !cred.Insecure {
+		cred.CAData = secret.Data[corev1.ServiceAccountRootCAKey]
+	}
+	endpoint, cred, err := cred.resolve(strings.TrimSpace(string(secret.Data["endpoint"])))
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	expiry, err := getClusterCredentialExpiry(cred)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if err = validateClusterCredential(ctx, endpoint, cred); err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("failed to access cluster %s with the new credential: %s", cluster.GetName(), err.Error()))
+	}
+	cred.applyToSecret(secret)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationClusterCredentialRotatedAt, time.Now().UTC().Format(time.RFC3339))
+	if expiry != nil {
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationClusterCredentialExpireAt, expiry.UTC().Format(time.RFC3339))
+	} else {
+		delete(secret.Annotations, AnnotationClusterCredentialExpireAt)
+	}
+	return c.Client.Update(ctx, secret)
+}
+
+// getReferringResourceTrackers returns the names of ResourceTrackers that
+// manage resources in the given cluster
+func (c *clusterClient) getReferringResourceTrackers(ctx context.Context, name string) ([]string, error) {
+	rts := &unstructured.UnstructuredList{}
+	rts.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
+	if err := c.Client.List(ctx, rts); err != nil {
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, nil
 		}
-	})
-	return clusters, nil
+		return nil, err
+	}
+	var trackers []string
+	for _, rt := range rts.Items {
+		resources, _, _ := unstructured.NestedSlice(rt.Object, "spec", "managedResources")
+		for _, r := range resources {
+			if m, ok := r.(map[string]interface{}); ok && m["cluster"] == name {
+				trackers = append(trackers, rt.GetName())
+				break
+			}
+		}
+	}
+	return trackers, nil
 }
 
 // clusterSelector filters the list/delete operation of cluster list
 type clusterSelector struct {
 	Selector              labels.Selector
+	FieldSelector         fields.Selector
 	RequireCredentialType bool
 	IgnoreNamespace       bool
 }
 
+// Matches check if the cluster matches the label selector and field selector
+func (m clusterSelector) Matches(cluster *Cluster) bool {
+	if m.Selector != nil && !m.Selector.Matches(labels.Set(cluster.GetLabels())) {
+		return false
+	}
+	if m.FieldSelector != nil && !m.FieldSelector.Matches(ClusterFieldsSet(cluster)) {
+		return false
+	}
+	return true
+}
+
 // ApplyToList applies this configuration to the given list options.
 func (m clusterSelector) ApplyToList(opts *client.ListOptions) {
 	opts.LabelSelector = labels.NewSelector()
 	if m.Selector != nil {
 		requirements, _ := m.Selector.Requirements()
 		for _, r := range requirements {
-			if !slices.Contains([]string{LabelClusterControlPlane}, r.Key()) {
+			if !slices.Contains(syntheticClusterLabels, r.Key()) {
 				opts.LabelSelector = opts.LabelSelector.Add(r)
 			}
 		}
@@ -166,7 +406,63 @@ func (m clusterSelector) ApplyToList(opts *client.ListOptions) {
 		r, _ := labels.NewRequirement(clustergatewaycommon.LabelKeyClusterCredentialType, selection.Exists, nil)
 		opts.LabelSelector = opts.LabelSelector.Add(*r)
 	}
+	if m.FieldSelector != nil {
+		// only metadata.name can be selected on the underlying objects
+		var selectors []fields.Selector
+		for _, r := range m.FieldSelector.Requirements() {
+			if r.Field != ClusterFieldMetadataName {
+				continue
+			}
+			switch r.Operator {
+			case selection.Equals, selection.DoubleEquals:
+				selectors = append(selectors, fields.OneTermEqualSelector(r.Field, r.Value))
+			case selection.NotEquals:
+				selectors = append(selectors, fields.OneTermNotEqualSelector(r.Field, r.Value))
+			}
+		}
+		if len(selectors) > 0 {
+			opts.FieldSelector = fields.AndSelectors(selectors...)
+		}
+	}
 	if !m.IgnoreNamespace {
 		opts.Namespace = StorageNamespace
 	}
 }
+
+// mergeClusters converts cluster secrets and OCM ManagedClusters into clusters.
+// If a cluster secret and a ManagedCluster share the same name, the cluster
+// secret takes precedence. Invalid objects are skipped.
+func mergeClusters(secrets []corev1.Secret, managedClusters []ocmclusterv1.ManagedCluster) []Cluster {
+	var clusters []Cluster
+	found := map[string]bool{}
+	for _, secret := range secrets {
+		if cluster, err := NewClusterFromSecret(secret.DeepCopy()); err == nil {
+			clusters = append(clusters, *cluster)
+			found[cluster.Name] = true
+		}
+	}
+	for _, managedCluster := range managedClusters {
+		if !found[managedCluster.Name] {
+			if cluster, err := NewClusterFromManagedCluster(managedCluster.DeepCopy()); err == nil {
+				clusters = append(clusters, *cluster)
+			}
+		}
+	}
+	return clusters
+}
+
+// maxResourceVersion returns the latest one among the given resource versions.
+// Cluster secrets and ManagedClusters are served by the same storage, so their
+// resource versions are comparable.
+func maxResourceVersion(resourceVersions ...string) string {
+	var latest uint64
+	for _, rv := range resourceVersions {
+		if v, err := strconv.ParseUint(rv, 10, 64); err == nil && v > latest {
+			latest = v
+		}
+	}
+	if latest == 0 {
+		return ""
+	}
+	return strconv.FormatUint(latest, 10)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/util/apiserver"
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// ClusterSet is a group of clusters selected by labels or listed explicitly
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSetSpec   `json:"spec,omitempty"`
+	Status ClusterSetStatus `json:"status,omitempty"`
+}
+
+// ClusterSetSpec spec of cluster set
+type ClusterSetSpec struct {
+	// Selector selects the member clusters by labels
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Clusters are the explicit member clusters, referred by name or alias
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// ClusterSetStatus status of cluster set
+type ClusterSetStatus struct {
+	// Clusters are the names of resolved member clusters
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// ClusterSetList list for ClusterSet
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterSet `json:"items"`
+}
+
+var _ resource.Object = &ClusterSet{}
+var _ rest.Getter = &ClusterSet{}
+var _ rest.Lister = &ClusterSet{}
+var _ rest.Creater = &ClusterSet{}
+var _ rest.Updater = &ClusterSet{}
+var _ rest.GracefulDeleter = &ClusterSet{}
+
+// GetObjectMeta returns the object meta reference.
+func (in *ClusterSet) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// NamespaceScoped returns if the object must be in a namespace.
+func (in *ClusterSet) NamespaceScoped() bool {
+	return false
+}
+
+// New returns a new instance of the resource
+func (in *ClusterSet) New() runtime.Object {
+	return &ClusterSet{}
+}
+
+// Destroy .
+func (in *ClusterSet) Destroy() {}
+
+// NewList return a new list instance of the resource
+func (in *ClusterSet) NewList() runtime.Object {
+	return &ClusterSetList{}
+}
+
+// GetGroupVersionResource returns the GroupVersionResource for this resource.
+func (in *ClusterSet) GetGroupVersionResource() schema.GroupVersionResource {
+	return GroupVersion.WithResource(ClusterSetResource)
+}
+
+// IsStorageVersion returns true if the object is also the internal version
+func (in *ClusterSet) IsStorageVersion() bool {
+	return true
+}
+
+// ShortNames delivers a list of short names for a resource.
+func (in *ClusterSet) ShortNames() []string {
+	return []string{"vcs", "vela-clusterset", "vela-clustersets"}
+}
+
+// Get finds a resource in the storage by name and returns it.
+func (in *ClusterSet) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return NewClusterSetClient(singleton.KubeClient.Get()).Get(ctx, name)
+}
+
+// List selects resources in the storage which match to the selector. 'options' can be nil.
+func (in *ClusterSet) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	return NewClusterSetClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+}
+
+// Create creates a new version of a resource.
+func (in *ClusterSet) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	clusterSet, ok := obj.(*ClusterSet)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a ClusterSet: %#v", obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	cli := NewClusterSetClient(singleton.KubeClient.Get())
+	if err := cli.Create(ctx, clusterSet); err != nil {
+		return nil, err
+	}
+	return cli.Get(ctx, clusterSet.GetName())
+}
+
+// Update finds a resource in the storage and updates it.
+func (in *ClusterSet) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cli := NewClusterSetClient(singleton.KubeClient.Get())
+	existing, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, existing.DeepCopy())
+	if err != nil {
+		return nil, false, err
+	}
+	clusterSet, ok := obj.(*ClusterSet)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a ClusterSet: %#v", obj))
+	}
+	if updateValidation != nil {
+		if err = updateValidation(ctx, clusterSet, existing); err != nil {
+			return nil, false, err
+		}
+	}
+	if err = cli.Update(ctx, clusterSet); err != nil {
+		return nil, false, err
+	}
+	obj, err = cli.Get(ctx, name)
+	return obj, false, err
+}
+
+// Delete finds a resource in the storage and deletes it.
+func (in *ClusterSet) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	cli := NewClusterSetClient(singleton.KubeClient.Get())
+	clusterSet, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if deleteValidation != nil {
+		if err = deleteValidation(ctx, clusterSet); err != nil {
+			return nil, false, err
+		}
+	}
+	if err = cli.Delete(ctx, clusterSet); err != nil {
+		return nil, false, err
+	}
+	return clusterSet, true, nil
+}
+
+// IsOCMManagedClusterSet check if the cluster set is mapped from OCM ManagedClusterSet
+func (in *ClusterSet) IsOCMManagedClusterSet() bool {
+	return in.GetLabels()[LabelClusterSetOCMManaged] == "true"
+}
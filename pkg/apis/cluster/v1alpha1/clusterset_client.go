@@ -0,0 +1,316 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	ocmclusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/apiserver"
+)
+
+const (
+	clusterSetConfigMapNamePrefix = "clusterset."
+	clusterSetSelectorKey         = "selector"
+	clusterSetClustersKey         = "clusters"
+)
+
+var (
+	// LabelClusterSetOCMManaged identifies the cluster set is mapped from OCM ManagedClusterSet
+	LabelClusterSetOCMManaged = "cluster." + Group + "/ocm-managed"
+	// LabelOCMClusterSet the label on OCM ManagedCluster that records its ManagedClusterSet
+	LabelOCMClusterSet = "cluster.open-cluster-management.io/clusterset"
+	// LabelClusterSetStorage identifies the configmap that stores a cluster set
+	LabelClusterSetStorage = "cluster." + Group + "/clusterset-storage"
+)
+
+// ClusterSetClient client for operating cluster sets
+// +kubebuilder:object:generate=false
+type ClusterSetClient interface {
+	Get(ctx context.Context, name string) (*ClusterSet, error)
+	List(ctx context.Context, options ...client.ListOption) (*ClusterSetList, error)
+	Create(ctx context.Context, clusterSet *ClusterSet) error
+	Update(ctx context.Context, clusterSet *ClusterSet) error
+	Delete(ctx context.Context, clusterSet *ClusterSet) error
+}
+
+type clusterSetClient struct {
+	client.Client
+}
+
+// NewClusterSetClient create a client for accessing cluster sets
+func NewClusterSetClient(cli client.Client) ClusterSetClient {
+	return &clusterSetClient{Client: cli}
+}
+
+func (c *clusterSetClient) Get(ctx context.Context, name string) (*ClusterSet, error) {
+	clusterSet, err := c.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := NewDefaultClusterClient().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clusterSet, clusterSet.resolve(clusters)
+}
+
+func (c *clusterSetClient) get(ctx context.Context, name string) (*ClusterSet, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Client.Get(ctx, apitypes.NamespacedName{Namespace: StorageNamespace, Name: clusterSetConfigMapNamePrefix + name}, cm)
+	if err == nil && cm.GetLabels()[LabelClusterSetStorage] == "true" {
+		return NewClusterSetFromConfigMap(cm)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	managedClusterSet := &ocmclusterv1beta1.ManagedClusterSet{}
+	if err = c.Client.Get(ctx, apitypes.NamespacedName{Name: name}, managedClusterSet); err == nil {
+		return NewClusterSetFromManagedClusterSet(managedClusterSet), nil
+	}
+	if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+		return nil, err
+	}
+	return nil, apierrors.NewNotFound(ClusterSetGroupResource, name)
+}
+
+func (c *clusterSetClient) List(ctx context.Context, options ...client.ListOption) (*ClusterSetList, error) {
+	opts := apiserver.NewListOptions(options...)
+	cms := &corev1.ConfigMapList{}
+	if err := c.Client.List(ctx, cms, client.InNamespace(StorageNamespace), client.MatchingLabels{LabelClusterSetStorage: "true"}); err != nil {
+		return nil, err
+	}
+	managedClusterSets := &ocmclusterv1beta1.ManagedClusterSetList{}
+	err := c.Client.List(ctx, managedClusterSets)
+	if err != nil && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+		return nil, err
+	}
+	clusters, err := NewDefaultClusterClient().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterSets := &ClusterSetList{}
+	found := map[string]bool{}
+	add := func(clusterSet *ClusterSet) error {
+		if opts.LabelSelector != nil && !opts.LabelSelector.Matches(labels.Set(clusterSet.GetLabels())) {
+			return nil
+		}
+		if err := clusterSet.resolve(clusters); err != nil {
+			return err
+		}
+		clusterSets.Items = append(clusterSets.Items, *clusterSet)
+		found[clusterSet.GetName()] = true
+		return nil
+	}
+	for _, cm := range cms.Items {
+		if clusterSet, err := NewClusterSetFromConfigMap(cm.DeepCopy()); err == nil {
+			if err = add(clusterSet); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, managedClusterSet := range managedClusterSets.Items {
+		if !found[managedClusterSet.GetName()] {
+			if err = add(NewClusterSetFromManagedClusterSet(managedClusterSet.DeepCopy())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(clusterSets.Items, func(i, j int) bool {
+		return clusterSets.Items[i].GetName() < clusterSets.Items[j].GetName()
+	})
+	return clusterSets, nil
+}
+
+func (c *clusterSetClient) Create(ctx context.Context, clusterSet *ClusterSet) error {
+	if clusterSet.IsOCMManagedClusterSet() {
+		return newReservedOCMManagedLabelError()
+	}
+	if _, err := c.get(ctx, clusterSet.GetName()); err == nil {
+		return apierrors.NewAlreadyExists(ClusterSetGroupResource, clusterSet.GetName())
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	cm, err := clusterSet.ToConfigMap()
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return c.Client.Create(ctx, cm)
+}
+
+func (c *clusterSetClient) Update(ctx context.Context, clusterSet *ClusterSet) error {
+	existing, err := c.get(ctx, clusterSet.GetName())
+	if err != nil {
+		return err
+	}
+	if existing.IsOCMManagedClusterSet() {
+		return apierrors.NewBadRequest(fmt.Sprintf("cluster set %s is managed by OCM and cannot be updated", clusterSet.GetName()))
+	}
+	if clusterSet.IsOCMManagedClusterSet() {
+		return newReservedOCMManagedLabelError()
+	}
+	cm, err := clusterSet.ToConfigMap()
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	cm.SetResourceVersion(clusterSet.GetResourceVersion())
+	return c.Client.Update(ctx, cm)
+}
+
+func newReservedOCMManagedLabelError() error {
+	return apierrors.NewBadRequest(fmt.Sprintf("label %s is reserved for cluster sets managed by OCM", LabelClusterSetOCMManaged))
+}
+
+func (c *clusterSetClient) Delete(ctx context.Context, clusterSet *ClusterSet) error {
+	if clusterSet.IsOCMManagedClusterSet() {
+		return apierrors.NewBadRequest(fmt.Sprintf("cluster set %s is managed by OCM and cannot be deleted", clusterSet.GetName()))
+	}
+	cm := &corev1.ConfigMap{}
+	cm.SetName(clusterSetConfigMapNamePrefix + clusterSet.GetName())
+	cm.SetNamespace(StorageNamespace)
+	return c.Client.Delete(ctx, cm)
+}
+
+// resolve fill the status of cluster set with the member clusters, which are
+// either selected by labels or listed explicitly by name or alias
+func (in *ClusterSet) resolve(clusters *ClusterList) error {
+	members := map[string]bool{}
+	if in.Spec.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(in.Spec.Selector)
+		if err != nil {
+			return apierrors.NewBadRequest(err.Error())
+		}
+		for _, cluster := range clusters.Items {
+			if sel.Matches(labels.Set(cluster.GetLabels())) {
+				members[cluster.GetName()] = true
+			}
+		}
+	}
+	for _, name := range in.Spec.Clusters {
+		if clusters.HasCluster(name) {
+			members[name] = true
+			continue
+		}
+		var matched []string
+		for _, cluster := range clusters.Items {
+			if cluster.Spec.Alias == name {
+				matched = append(matched, cluster.GetName())
+			}
+		}
+		if len(matched) == 1 {
+			members[matched[0]] = true
+		}
+	}
+	in.Status.Clusters = nil
+	for name := range members {
+		in.Status.Clusters = append(in.Status.Clusters, name)
+	}
+	sort.Strings(in.Status.Clusters)
+	return nil
+}
+
+// ToConfigMap convert cluster set into the underlying configmap. Only the
+// name, labels and annotations of the cluster set are kept in the configmap,
+// which is marked by LabelClusterSetStorage.
+func (in *ClusterSet) ToConfigMap() (*corev1.ConfigMap, error) {
+	if in.Spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(in.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+	objectMeta := in.ObjectMeta.DeepCopy()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clusterSetConfigMapNamePrefix + in.GetName(),
+			Namespace:   StorageNamespace,
+			Labels:      objectMeta.Labels,
+			Annotations: objectMeta.Annotations,
+		},
+		Data: map[string]string{},
+	}
+	metav1.SetMetaDataLabel(&cm.ObjectMeta, LabelClusterSetStorage, "true")
+	if in.Spec.Selector != nil {
+		bs, err := json.Marshal(in.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[clusterSetSelectorKey] = string(bs)
+	}
+	if len(in.Spec.Clusters) > 0 {
+		bs, err := json.Marshal(in.Spec.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		cm.Data[clusterSetClustersKey] = string(bs)
+	}
+	return cm, nil
+}
+
+// NewClusterSetFromConfigMap create cluster set from configmap
+func NewClusterSetFromConfigMap(cm *corev1.ConfigMap) (*ClusterSet, error) {
+	if !strings.HasPrefix(cm.GetName(), clusterSetConfigMapNamePrefix) {
+		return nil, NewInvalidClusterSetConfigMapNameError()
+	}
+	clusterSet := &ClusterSet{}
+	clusterSet.ObjectMeta = *cm.ObjectMeta.DeepCopy()
+	clusterSet.SetGroupVersionKind(ClusterSetGroupVersionKind)
+	clusterSet.SetName(strings.TrimPrefix(cm.GetName(), clusterSetConfigMapNamePrefix))
+	clusterSet.SetNamespace("")
+	clusterSet.SetManagedFields(nil)
+	delete(clusterSet.Labels, LabelClusterSetStorage)
+	if data := cm.Data[clusterSetSelectorKey]; data != "" {
+		clusterSet.Spec.Selector = &metav1.LabelSelector{}
+		if err := json.Unmarshal([]byte(data), clusterSet.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+	if data := cm.Data[clusterSetClustersKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &clusterSet.Spec.Clusters); err != nil {
+			return nil, err
+		}
+	}
+	return clusterSet, nil
+}
+
+// NewClusterSetFromManagedClusterSet create cluster set from OCM ManagedClusterSet
+func NewClusterSetFromManagedClusterSet(managedClusterSet *ocmclusterv1beta1.ManagedClusterSet) *ClusterSet {
+	clusterSet := &ClusterSet{}
+	clusterSet.SetGroupVersionKind(ClusterSetGroupVersionKind)
+	clusterSet.SetName(managedClusterSet.GetName())
+	clusterSet.SetCreationTimestamp(managedClusterSet.GetCreationTimestamp())
+	clusterSet.SetResourceVersion(managedClusterSet.GetResourceVersion())
+	clusterSet.SetLabels(managedClusterSet.GetLabels())
+	metav1.SetMetaDataLabel(&clusterSet.ObjectMeta, LabelClusterSetOCMManaged, "true")
+	clusterSet.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{LabelOCMClusterSet: managedClusterSet.GetName()},
+	}
+	return clusterSet
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	"github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
 	"github.com/oam-dev/cluster-gateway/pkg/config"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -36,16 +39,38 @@ const (
 var (
 	// AnnotationClusterAlias the annotation key for cluster alias
 	AnnotationClusterAlias = config.MetaApiGroupName + "/cluster-alias"
+	// AnnotationClusterCredentialRotatedAt the annotation key for the last rotation time of cluster credential
+	AnnotationClusterCredentialRotatedAt = config.MetaApiGroupName + "/credential-rotated-at"
+	// AnnotationClusterCredentialExpireAt the annotation key for the expiry time of cluster credential
+	AnnotationClusterCredentialExpireAt = config.MetaApiGroupName + "/credential-expire-at"
 
 	// LabelClusterControlPlane identifies whether the cluster is the control plane
 	LabelClusterControlPlane = config.MetaApiGroupName + "/control-plane"
+	// LabelClusterCertExpiring identifies whether the client certificate of the cluster is expiring soon
+	LabelClusterCertExpiring = "cluster." + Group + "/cert-expiring"
+
+	// syntheticClusterLabels are labels computed by prism, which do not exist on the underlying objects
+	syntheticClusterLabels = []string{LabelClusterControlPlane, LabelClusterCertExpiring}
+
+	// ResourceTrackerGroupVersionKind the gvk of KubeVela ResourceTracker, which records resources dispatched to clusters
+	ResourceTrackerGroupVersionKind = schema.GroupVersionKind{Group: "core.oam.dev", Version: "v1beta1", Kind: "ResourceTracker"}
 )
 
 // StorageNamespace refers to the namespace of cluster secret, usually same as the core kubevela system namespace
 var StorageNamespace = "vela-system"
 
+// ClusterCertExpiringThreshold the duration before the expiry of client certificate to report the cluster as expiring soon
+var ClusterCertExpiringThreshold = 30 * 24 * time.Hour
+
+// EnableClusterCache whether to serve clusters from the informer-backed cache
+var EnableClusterCache = false
+
 // AddClusterFlags add flags for cluster api
 func AddClusterFlags(set *pflag.FlagSet) {
 	set.StringVarP(&StorageNamespace, "storage-namespace", "", "vela-system",
 		"The namespace that stores cluster secrets or OCM ManagedClusters.")
+	set.DurationVarP(&ClusterCertExpiringThreshold, "cluster-cert-expiring-threshold", "", 30*24*time.Hour,
+		"The duration before the expiry of cluster client certificate to report the cluster as expiring soon.")
+	set.BoolVarP(&EnableClusterCache, "enable-cluster-cache", "", false,
+		"If enabled, clusters will be served from the informer-backed cache instead of listing cluster secrets and OCM ManagedClusters on each request.")
 }
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	clustergatewayv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes"
+	clientrest "k8s.io/client-go/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+)
+
+const (
+	// ClusterCredentialSubResource the name of the subresource for rotating cluster credential
+	ClusterCredentialSubResource = "credential"
+)
+
+// ClusterCredentialRotation the write-only subresource for rotating the
+// credential of cluster. The new credential is validated against the cluster
+// endpoint before it is stored.
+// +kubebuilder:object:generate=false
+type ClusterCredentialRotation struct{}
+
+var _ resource.ArbitrarySubResource = &ClusterCredentialRotation{}
+var _ rest.Updater = &ClusterCredentialRotation{}
+
+// SubResourceName returns the name of the subresource
+func (in *ClusterCredentialRotation) SubResourceName() string {
+	return ClusterCredentialSubResource
+}
+
+// New returns a new instance of the resource
+func (in *ClusterCredentialRotation) New() runtime.Object {
+	return &Cluster{}
+}
+
+// Destroy .
+func (in *ClusterCredentialRotation) Destroy() {}
+
+// Update replaces the credential of the cluster with spec.credential of the
+// given cluster. Other fields are ignored. The cluster is found by the exact
+// name rather than alias.
+func (in *ClusterCredentialRotation) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cli := NewDefaultClusterClient()
+	existing, err := cli.GetByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, existing.DeepCopy())
+	if err != nil {
+		return nil, false, err
+	}
+	updated, ok := obj.(*Cluster)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a Cluster: %#v", obj))
+	}
+	if updated.Spec.Credential == nil {
+		return nil, false, apierrors.NewBadRequest("spec.credential is required for rotating cluster credential")
+	}
+	cluster := existing.DeepCopy()
+	cluster.Spec.Credential = updated.Spec.Credential
+	if err = cli.RotateCredential(ctx, cluster); err != nil {
+		return nil, false, err
+	}
+	obj, err = cli.GetByName(ctx, cluster.GetName())
+	return obj, false, err
+}
+
+// validateClusterCredential checks if the credential can access the cluster
+// endpoint. The discovery api is used as it is not open to anonymous users.
+// The cluster certificate is verified against the given CA, or the system roots
+// if no CA is given, unless the credential explicitly sets insecure.
+func validateClusterCredential(ctx context.Context, endpoint string, cred *ClusterCredential) error {
+	ctx, cancel := context.WithTimeout(ctx, ClusterStatusProbeTimeout)
+	defer cancel()
+	cfg := &clientrest.Config{
+		Host:        endpoint,
+		BearerToken: cred.Token,
+		TLSClientConfig: clientrest.TLSClientConfig{
+			Insecure: cred.Insecure,
+			CertData: cred.CertData,
+			KeyData:  cred.KeyData,
+		},
+	}
+	if !cred.Insecure {
+		cfg.CAData = cred.CAData
+	}
+	cli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return cli.Discovery().RESTClient().Get().AbsPath("/api").Do(ctx).Error()
+}
+
+// getClusterCredentialExpiry returns the expiry time of the credential. For
+// X509 certificates, it is the NotAfter of the certificate. For tokens, it is
+// the exp claim if the token is a JWT. Nil is returned if there is no expiry.
+func getClusterCredentialExpiry(cred *ClusterCredential) (*time.Time, error) {
+	switch cred.getCredentialType() {
+	case clustergatewayv1alpha1.CredentialTypeX509Certificate:
+		return getCertificateExpiry(cred.CertData)
+	case clustergatewayv1alpha1.CredentialTypeServiceAccountToken:
+		return getTokenExpiry(cred.Token), nil
+	}
+	return nil, nil
+}
+
+func getCertificateExpiry(certData []byte) (*time.Time, error) {
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return nil, NewInvalidClusterCredentialError("failed to decode certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, NewInvalidClusterCredentialError(err.Error())
+	}
+	return &cert.NotAfter, nil
+}
+
+func getTokenExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err = json.Unmarshal(bs, &claims); err != nil || claims.Exp == 0 {
+		return nil
+	}
+	t := time.Unix(claims.Exp, 0).UTC()
+	return &t
+}
@@ -60,3 +60,37 @@ func NewInvalidManagedClusterError() error {
 func IsInvalidManagedClusterError(err error) bool {
 	return errors.As(err, &invalidManagedClusterError{})
 }
+
+type invalidClusterCredentialError struct {
+	reason string
+}
+
+func (e invalidClusterCredentialError) Error() string {
+	return "invalid cluster credential: " + e.reason
+}
+
+// NewInvalidClusterCredentialError create an invalid cluster credential error
+func NewInvalidClusterCredentialError(reason string) error {
+	return invalidClusterCredentialError{reason: reason}
+}
+
+// IsInvalidClusterCredentialError check if an error is an invalid cluster credential error
+func IsInvalidClusterCredentialError(err error) bool {
+	return errors.As(err, &invalidClusterCredentialError{})
+}
+
+type invalidClusterSetConfigMapNameError struct{}
+
+func (e invalidClusterSetConfigMapNameError) Error() string {
+	return "configmap is not a valid cluster set configmap, name should be prefixed with " + clusterSetConfigMapNamePrefix
+}
+
+// NewInvalidClusterSetConfigMapNameError create an invalid cluster set configmap error due to invalid name
+func NewInvalidClusterSetConfigMapNameError() error {
+	return invalidClusterSetConfigMapNameError{}
+}
+
+// IsInvalidClusterSetConfigMapNameError check if an error is an invalid cluster set configmap name error
+func IsInvalidClusterSetConfigMapNameError(err error) bool {
+	return errors.As(err, &invalidClusterSetConfigMapNameError{})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterListContinue is the continue token of cluster list. Clusters are
+// returned in name order, so the token records the last returned name and
+// the page of each backing source that has not been consumed completely.
+type clusterListContinue struct {
+	After           string             `json:"after"`
+	Secrets         clusterPagerCursor `json:"secrets"`
+	ManagedClusters clusterPagerCursor `json:"managedClusters"`
+}
+
+type clusterPagerCursor struct {
+	Continue string `json:"continue,omitempty"`
+	Done     bool   `json:"done,omitempty"`
+}
+
+func decodeClusterListContinue(token string) (*clusterListContinue, error) {
+	c := &clusterListContinue{}
+	if token == "" {
+		return c, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continue token: %w", err)
+	}
+	if err = json.Unmarshal(bs, c); err != nil {
+		return nil, fmt.Errorf("invalid continue token: %w", err)
+	}
+	return c, nil
+}
+
+func (in *clusterListContinue) encode() (string, error) {
+	bs, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bs), nil
+}
+
+// clusterPager iterates the objects of one backing source page by page. The
+// kube-apiserver returns objects of one namespace (or cluster-scoped objects)
+// in name order, which allows pages of different sources to be merged.
+type clusterPager struct {
+	list func(ctx context.Context, continueToken string) ([]client.Object, string, string, error)
+
+	cursor          clusterPagerCursor
+	after           string
+	loaded          bool
+	items           []client.Object
+	next            string
+	resourceVersion string
+}
+
+// peek returns the next object with name greater than the last returned one,
+// or nil if the source is exhausted
+func (p *clusterPager) peek(ctx context.Context) (client.Object, error) {
+	for !p.cursor.Done {
+		if !p.loaded {
+			items, next, rv, err := p.list(ctx, p.cursor.Continue)
+			if err != nil {
+				return nil, err
+			}
+			p.items, p.next, p.loaded = items, next, true
+			if p.resourceVersion == "" {
+				p.resourceVersion = rv
+			}
+		}
+		for len(p.items) > 0 && p.items[0].GetName() <= p.after {
+			p.items = p.items[1:]
+		}
+		if len(p.items) > 0 {
+			return p.items[0], nil
+		}
+		if p.next == "" {
+			p.cursor = clusterPagerCursor{Done: true}
+		} else {
+			p.cursor, p.loaded = clusterPagerCursor{Continue: p.next}, false
+		}
+	}
+	return nil, nil
+}
+
+// pop consumes the next object
+func (p *clusterPager) pop() {
+	if len(p.items) > 0 {
+		p.after = p.items[0].GetName()
+		p.items = p.items[1:]
+	}
+}
+
+func (c *clusterClient) newSecretPager(after string, cursor clusterPagerCursor, limit int64, sel clusterSelector) *clusterPager {
+	sel.RequireCredentialType, sel.IgnoreNamespace = true, false
+	return &clusterPager{
+		after:  after,
+		cursor: cursor,
+		list: func(ctx context.Context, continueToken string) ([]client.Object, string, string, error) {
+			secrets := &corev1.SecretList{}
+			if err := c.Client.List(ctx, secrets, sel, client.Limit(limit), client.Continue(continueToken)); err != nil {
+				return nil, "", "", err
+			}
+			var objs []client.Object
+			for i := range secrets.Items {
+				objs = append(objs, &secrets.Items[i])
+			}
+			return objs, secrets.Continue, secrets.ResourceVersion, nil
+		},
+	}
+}
+
+func (c *clusterClient) newManagedClusterPager(after string, cursor clusterPagerCursor, limit int64, sel clusterSelector) *clusterPager {
+	sel.RequireCredentialType, sel.IgnoreNamespace = false, true
+	return &clusterPager{
+		after:  after,
+		cursor: cursor,
+		list: func(ctx context.Context, continueToken string) ([]client.Object, string, string, error) {
+			managedClusters := &ocmclusterv1.ManagedClusterList{}
+			err := c.Client.List(ctx, managedClusters, sel, client.Limit(limit), client.Continue(continueToken))
+			if err != nil {
+				if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+					return nil, "", "", nil
+				}
+				return nil, "", "", err
+			}
+			var objs []client.Object
+			for i := range managedClusters.Items {
+				objs = append(objs, &managedClusters.Items[i])
+			}
+			return objs, managedClusters.Continue, managedClusters.ResourceVersion, nil
+		},
+	}
+}
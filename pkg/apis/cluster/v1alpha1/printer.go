@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -44,11 +46,30 @@ var (
 		{Name: "Credential_Type", Type: "string", Description: "the credential type"},
 		{Name: "Endpoint", Type: "string", Description: "the endpoint"},
 		{Name: "Accepted", Type: "boolean", Description: "the acceptance of the cluster"},
+		{Name: "Ready", Type: "string", Description: "the reachability of the cluster"},
+		{Name: "Version", Type: "string", Description: "the kubernetes version of the cluster"},
+		{Name: "Cert_Expiry", Type: "string", Description: "the expiry time of the client certificate"},
 		{Name: "Labels", Type: "string", Description: "the labels of the cluster"},
 		{Name: "Creation_Timestamp", Type: "dateTime", Description: "the creation timestamp of the cluster", Priority: 10},
 	}
 )
 
+// GetReadyStatus returns the status of the reachable condition
+func (in *Cluster) GetReadyStatus() string {
+	if condition := meta.FindStatusCondition(in.Status.Conditions, ClusterConditionTypeReachable); condition != nil {
+		return string(condition.Status)
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// GetCertExpiry returns the expiry time of the client certificate
+func (in *Cluster) GetCertExpiry() string {
+	if in.Status.CertNotAfter == nil {
+		return ""
+	}
+	return in.Status.CertNotAfter.UTC().Format(time.RFC3339)
+}
+
 func printCluster(in *Cluster) *metav1.Table {
 	return &metav1.Table{
 		ColumnDefinitions: definitions,
@@ -80,7 +101,60 @@ func printClusterRow(c *Cluster) metav1.TableRow {
 		c.Spec.CredentialType,
 		c.Spec.Endpoint,
 		c.Spec.Accepted,
+		c.GetReadyStatus(),
+		c.Status.Version,
+		c.GetCertExpiry(),
 		strings.Join(labels, ","),
 		c.GetCreationTimestamp())
 	return row
 }
+
+// ConvertToTable convert resource to table
+func (in *ClusterSet) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	switch obj := object.(type) {
+	case *ClusterSet:
+		return printClusterSet(obj), nil
+	case *ClusterSetList:
+		return printClusterSetList(obj), nil
+	default:
+		return nil, fmt.Errorf("unknown type %T", object)
+	}
+}
+
+var (
+	clusterSetDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: "the name of the cluster set"},
+		{Name: "OCM_Managed", Type: "boolean", Description: "whether the cluster set is mapped from OCM ManagedClusterSet"},
+		{Name: "Clusters", Type: "string", Description: "the member clusters"},
+		{Name: "Creation_Timestamp", Type: "dateTime", Description: "the creation timestamp of the cluster set", Priority: 10},
+	}
+)
+
+func printClusterSet(in *ClusterSet) *metav1.Table {
+	return &metav1.Table{
+		ColumnDefinitions: clusterSetDefinitions,
+		Rows:              []metav1.TableRow{printClusterSetRow(in)},
+	}
+}
+
+func printClusterSetList(in *ClusterSetList) *metav1.Table {
+	t := &metav1.Table{
+		ColumnDefinitions: clusterSetDefinitions,
+	}
+	for _, c := range in.Items {
+		t.Rows = append(t.Rows, printClusterSetRow(c.DeepCopy()))
+	}
+	return t
+}
+
+func printClusterSetRow(c *ClusterSet) metav1.TableRow {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: c},
+	}
+	row.Cells = append(row.Cells,
+		c.Name,
+		c.IsOCMManagedClusterSet(),
+		strings.Join(c.Status.Clusters, ","),
+		c.GetCreationTimestamp())
+	return row
+}
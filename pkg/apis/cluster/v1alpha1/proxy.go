@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	clustergatewayv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustergatewayconfig "github.com/oam-dev/cluster-gateway/pkg/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiproxy "k8s.io/apimachinery/pkg/util/proxy"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes"
+	clientrest "k8s.io/client-go/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+const (
+	// ClusterProxySubResource the name of the subresource for proxying requests to the cluster
+	ClusterProxySubResource = "proxy"
+)
+
+var clusterProxyMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// ClusterProxy the subresource for proxying requests to the cluster on behalf
+// of the caller. The caller only needs the permission of clusters/proxy, the
+// access of the cluster is resolved through cluster-gateway and the identity
+// of the caller is forwarded to the cluster by impersonation.
+// +kubebuilder:object:generate=false
+type ClusterProxy struct{}
+
+// ClusterProxyOptions the options for proxying requests to the cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterProxyOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Path is the target api path of the proxy request, e.g. "/api/v1"
+	Path string `json:"path"`
+}
+
+var _ resource.ArbitrarySubResource = &ClusterProxy{}
+var _ rest.Connecter = &ClusterProxy{}
+var _ resource.QueryParameterObject = &ClusterProxyOptions{}
+
+// SubResourceName returns the name of the subresource
+func (in *ClusterProxy) SubResourceName() string {
+	return ClusterProxySubResource
+}
+
+// New returns a new instance of the resource
+func (in *ClusterProxy) New() runtime.Object {
+	return &ClusterProxyOptions{}
+}
+
+// Destroy .
+func (in *ClusterProxy) Destroy() {}
+
+// NewConnectOptions returns the options of the proxy request, the sub path
+// of the request is used as the target path
+func (in *ClusterProxy) NewConnectOptions() (runtime.Object, bool, string) {
+	return &ClusterProxyOptions{}, true, "path"
+}
+
+// ConnectMethods returns the methods supported by the proxy
+func (in *ClusterProxy) ConnectMethods() []string {
+	return clusterProxyMethods
+}
+
+// Connect authorizes the caller against the cluster and returns the handler
+// that forwards the request to the cluster
+func (in *ClusterProxy) Connect(ctx context.Context, name string, options runtime.Object, r rest.Responder) (http.Handler, error) {
+	opts, ok := options.(*ClusterProxyOptions)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid options object: %#v", options))
+	}
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewUnauthorized("no user found in request")
+	}
+	cluster, err := NewDefaultClusterClient().Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	// the request could refer the cluster by alias, so the authorization is
+	// checked again against the actual cluster
+	verb := "get"
+	if info, found := request.RequestInfoFrom(ctx); found {
+		verb = info.Verb
+	}
+	decision, reason, err := loopback.GetAuthorizer().Authorize(ctx, authorizer.AttributesRecord{
+		User:            user,
+		Verb:            verb,
+		APIGroup:        Group,
+		APIVersion:      Version,
+		Resource:        ClusterResource,
+		Subresource:     ClusterProxySubResource,
+		Name:            cluster.GetName(),
+		ResourceRequest: true,
+	})
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if decision != authorizer.DecisionAllow {
+		return nil, apierrors.NewForbidden(ClusterGroupResource, cluster.GetName(), fmt.Errorf("%s", reason))
+	}
+
+	cfg, location, err := newClusterProxyConfig(ctx, cluster.GetName())
+	if err != nil {
+		return nil, err
+	}
+	cfg.Impersonate = clientrest.ImpersonationConfig{
+		UserName: user.GetName(),
+		UID:      user.GetUID(),
+		Groups:   user.GetGroups(),
+		Extra:    user.GetExtra(),
+	}
+	rt, err := clientrest.TransportFor(cfg)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	location.Path = path.Join(location.Path, opts.Path)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		loc := *location
+		loc.RawQuery = req.URL.RawQuery
+		proxy := apiproxy.NewUpgradeAwareHandler(&loc, rt, false, false, proxyErrorResponder{responder: r})
+		proxy.ServeHTTP(w, req)
+	}), nil
+}
+
+// newClusterProxyConfig returns the config and the endpoint for accessing the
+// cluster. The local cluster is accessed directly while the access of other
+// clusters is resolved from the ClusterGateway.
+func newClusterProxyConfig(ctx context.Context, name string) (*clientrest.Config, *url.URL, error) {
+	if name == ClusterLocalName {
+		cfg := clientrest.CopyConfig(singleton.KubeConfig.Get())
+		location, err := url.Parse(cfg.Host)
+		if err != nil {
+			return nil, nil, apierrors.NewInternalError(err)
+		}
+		return cfg, location, nil
+	}
+	cli, err := kubernetes.NewForConfig(singleton.KubeConfig.Get())
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError(err)
+	}
+	bs, err := cli.Discovery().RESTClient().Get().AbsPath(
+		"/apis", clustergatewayconfig.MetaApiGroupName, clustergatewayconfig.MetaApiVersionName,
+		clustergatewayconfig.MetaApiResourceName, name).Do(ctx).Raw()
+	if err != nil {
+		return nil, nil, err
+	}
+	gateway := &clustergatewayv1alpha1.ClusterGateway{}
+	if err = json.Unmarshal(bs, gateway); err != nil {
+		return nil, nil, apierrors.NewInternalError(err)
+	}
+	if gateway.Spec.Access.Endpoint.Type != clustergatewayv1alpha1.ClusterEndpointTypeConst || gateway.Spec.Access.Credential == nil {
+		return nil, nil, apierrors.NewBadRequest(fmt.Sprintf("proxying cluster %s is not supported", name))
+	}
+	cfg, err := clustergatewayv1alpha1.NewConfigFromCluster(ctx, gateway)
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError(err)
+	}
+	location, err := clustergatewayv1alpha1.GetEndpointURL(gateway)
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError(err)
+	}
+	return cfg, location, nil
+}
+
+type proxyErrorResponder struct {
+	responder rest.Responder
+}
+
+// Error implements apiproxy.ErrorResponder
+func (in proxyErrorResponder) Error(_ http.ResponseWriter, _ *http.Request, err error) {
+	in.responder.Error(err)
+}
+
+// ConvertFromUrlValues parse the options from query parameters
+func (in *ClusterProxyOptions) ConvertFromUrlValues(values *url.Values) error {
+	in.Path = values.Get("path")
+	return nil
+}
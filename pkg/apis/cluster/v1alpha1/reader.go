@@ -28,27 +28,46 @@ import (
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/strings/slices"
 	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubevela/pkg/util/apiserver"
-	"github.com/kubevela/pkg/util/singleton"
 )
 
 // Get finds a resource in the storage by name and returns it.
 func (in *Cluster) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
-	return NewClusterClient(singleton.KubeClient.Get()).Get(ctx, name)
+	cluster, err := NewDefaultClusterClient().Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	cluster.loadCachedStatus()
+	return cluster, nil
 }
 
 // List selects resources in the storage which match to the selector. 'options' can be nil.
 func (in *Cluster) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
-	return NewClusterClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+	opts := []client.ListOption{apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options)}
+	if options != nil {
+		if options.FieldSelector != nil && !options.FieldSelector.Empty() {
+			opts = append(opts, client.MatchingFieldsSelector{Selector: options.FieldSelector})
+		}
+		opts = append(opts, client.Limit(options.Limit), client.Continue(options.Continue))
+	}
+	clusters, err := NewDefaultClusterClient().List(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters.Items {
+		clusters.Items[i].loadCachedStatus()
+	}
+	return clusters, nil
 }
 
 func extractLabels(labels map[string]string) map[string]string {
 	_labels := make(map[string]string)
 	for k, v := range labels {
-		if !strings.HasPrefix(k, clustergatewayconfig.MetaApiGroupName) {
+		if !strings.HasPrefix(k, clustergatewayconfig.MetaApiGroupName) && !slices.Contains(syntheticClusterLabels, k) {
 			_labels[k] = v
 		}
 	}
@@ -61,9 +80,15 @@ func newCluster(obj client.Object) *Cluster {
 	if obj != nil {
 		cluster.SetName(obj.GetName())
 		cluster.SetCreationTimestamp(obj.GetCreationTimestamp())
+		cluster.SetResourceVersion(obj.GetResourceVersion())
 		cluster.SetLabels(extractLabels(obj.GetLabels()))
 		if annotations := obj.GetAnnotations(); annotations != nil {
 			cluster.Spec.Alias = annotations[AnnotationClusterAlias]
+			for _, key := range []string{AnnotationClusterCredentialRotatedAt, AnnotationClusterCredentialExpireAt} {
+				if val, found := annotations[key]; found {
+					metav1.SetMetaDataAnnotation(&cluster.ObjectMeta, key, val)
+				}
+			}
 		}
 	}
 	cluster.Spec.Accepted = true
@@ -95,6 +120,11 @@ func NewClusterFromSecret(secret *corev1.Secret) (*Cluster, error) {
 	}
 	cluster.Spec.CredentialType = clustergatewayv1alpha1.CredentialType(
 		secret.GetLabels()[clustergatewaycommon.LabelKeyClusterCredentialType])
+	if cluster.Spec.CredentialType == clustergatewayv1alpha1.CredentialTypeX509Certificate {
+		if notAfter, err := getCertificateExpiry(secret.Data[corev1.TLSCertKey]); err == nil {
+			cluster.setCertificateExpiry(*notAfter)
+		}
+	}
 	return cluster, nil
 }
 
@@ -106,5 +136,6 @@ func NewClusterFromManagedCluster(managedCluster *ocmclusterv1.ManagedCluster) (
 	cluster := newCluster(managedCluster)
 	cluster.Spec.Accepted = managedCluster.Spec.HubAcceptsClient
 	cluster.Spec.CredentialType = CredentialTypeOCMManagedCluster
+	cluster.Status = newClusterStatusFromManagedCluster(managedCluster)
 	return cluster, nil
 }
@@ -17,12 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+	ocmclusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 )
 
 const (
@@ -42,15 +47,23 @@ func init() {
 var AddToScheme = func(scheme *runtime.Scheme) error {
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	metav1.AddToGroupVersion(scheme, ocmclusterv1.GroupVersion)
+	metav1.AddToGroupVersion(scheme, ocmclusterv1beta1.GroupVersion)
 	// +kubebuilder:scaffold:install
 	scheme.AddKnownTypes(GroupVersion,
 		&Cluster{},
 		&ClusterList{},
+		&ClusterProxyOptions{},
+		&ClusterSet{},
+		&ClusterSetList{},
 	)
 	scheme.AddKnownTypes(ocmclusterv1.GroupVersion,
 		&ocmclusterv1.ManagedCluster{},
 		&ocmclusterv1.ManagedClusterList{},
 	)
+	scheme.AddKnownTypes(ocmclusterv1beta1.GroupVersion,
+		&ocmclusterv1beta1.ManagedClusterSet{},
+		&ocmclusterv1beta1.ManagedClusterSetList{},
+	)
 	return nil
 }
 
@@ -66,4 +79,46 @@ var (
 	ClusterGroupResource = schema.GroupResource{Group: Group, Resource: ClusterResource}
 	// ClusterGroupVersionKind GroupVersionKind for Cluster
 	ClusterGroupVersionKind = GroupVersion.WithKind(ClusterKind)
+
+	// ClusterSetResource resource name for ClusterSet
+	ClusterSetResource = "clustersets"
+	// ClusterSetKind kind name for ClusterSet
+	ClusterSetKind = "ClusterSet"
+	// ClusterSetGroupResource GroupResource for ClusterSet
+	ClusterSetGroupResource = schema.GroupResource{Group: Group, Resource: ClusterSetResource}
+	// ClusterSetGroupVersionKind GroupVersionKind for ClusterSet
+	ClusterSetGroupVersionKind = GroupVersion.WithKind(ClusterSetKind)
+)
+
+const (
+	// ClusterFieldMetadataName field selector key for the name of cluster
+	ClusterFieldMetadataName = "metadata.name"
+	// ClusterFieldAlias field selector key for the alias of cluster
+	ClusterFieldAlias = "spec.alias"
+	// ClusterFieldAccepted field selector key for the acceptance of cluster
+	ClusterFieldAccepted = "spec.accepted"
+	// ClusterFieldCredentialType field selector key for the credential type of cluster
+	ClusterFieldCredentialType = "spec.credential-type"
 )
+
+// ClusterFieldsSet returns the fields of cluster that can be used in field selectors
+func ClusterFieldsSet(cluster *Cluster) fields.Set {
+	return fields.Set{
+		ClusterFieldMetadataName:   cluster.GetName(),
+		ClusterFieldAlias:          cluster.Spec.Alias,
+		ClusterFieldAccepted:       strconv.FormatBool(cluster.Spec.Accepted),
+		ClusterFieldCredentialType: string(cluster.Spec.CredentialType),
+	}
+}
+
+// AddClusterFieldLabelConversionFunc register the supported field selectors of cluster into the scheme
+func AddClusterFieldLabelConversionFunc(scheme *runtime.Scheme) error {
+	return scheme.AddFieldLabelConversionFunc(ClusterGroupVersionKind, func(label, value string) (string, string, error) {
+		switch label {
+		case ClusterFieldMetadataName, ClusterFieldAlias, ClusterFieldAccepted, ClusterFieldCredentialType:
+			return label, value, nil
+		default:
+			return "", "", fmt.Errorf("field label not supported for %s: %s", ClusterKind, label)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes"
+	clientrest "k8s.io/client-go/rest"
+	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	"github.com/kubevela/pkg/multicluster"
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+const (
+	// ClusterConditionTypeReachable identifies whether the cluster can be reached
+	ClusterConditionTypeReachable = "Reachable"
+	// ClusterConditionTypeExpiringSoon identifies whether the client certificate of the cluster is expiring soon
+	ClusterConditionTypeExpiringSoon = "ExpiringSoon"
+)
+
+var (
+	// ClusterStatusProbeTimeout the timeout for probing the status of one cluster
+	ClusterStatusProbeTimeout = 5 * time.Second
+	// ClusterStatusCacheTTL the duration for reusing the last probed cluster status
+	ClusterStatusCacheTTL = time.Minute
+)
+
+var clusterStatusCache = struct {
+	sync.RWMutex
+	items map[string]*ClusterStatus
+}{items: map[string]*ClusterStatus{}}
+
+func getCachedClusterStatus(name string) *ClusterStatus {
+	clusterStatusCache.RLock()
+	defer clusterStatusCache.RUnlock()
+	return clusterStatusCache.items[name].DeepCopy()
+}
+
+func setCachedClusterStatus(name string, status *ClusterStatus) {
+	clusterStatusCache.Lock()
+	defer clusterStatusCache.Unlock()
+	clusterStatusCache.items[name] = status.DeepCopy()
+}
+
+func deleteCachedClusterStatus(name string) {
+	clusterStatusCache.Lock()
+	defer clusterStatusCache.Unlock()
+	delete(clusterStatusCache.items, name)
+}
+
+// LoadStatus fill the status of the cluster. The status of OCM ManagedCluster
+// is reported by OCM itself. Other clusters are probed through cluster-gateway
+// if the cached status is outdated or force is set.
+func (in *Cluster) LoadStatus(ctx context.Context, force bool) {
+	if in.Spec.CredentialType == CredentialTypeOCMManagedCluster {
+		return
+	}
+	status := getCachedClusterStatus(in.Name)
+	if force || status == nil || status.LastProbeTime == nil || time.Since(status.LastProbeTime.Time) > ClusterStatusCacheTTL {
+		probed := ProbeClusterStatus(ctx, in.Name)
+		if status != nil {
+			conditions := status.Conditions
+			for _, condition := range probed.Conditions {
+				meta.SetStatusCondition(&conditions, condition)
+			}
+			probed.Conditions = conditions
+		}
+		status = probed
+		setCachedClusterStatus(in.Name, status)
+	}
+	in.Status.mergeProbedStatus(status)
+}
+
+// loadCachedStatus fill the status of the cluster with the last probed one
+func (in *Cluster) loadCachedStatus() {
+	if in.Spec.CredentialType == CredentialTypeOCMManagedCluster {
+		return
+	}
+	if status := getCachedClusterStatus(in.Name); status != nil {
+		in.Status.mergeProbedStatus(status)
+	}
+}
+
+// mergeProbedStatus fill the status with the probed one, conditions that are
+// not probed such as ExpiringSoon are kept
+func (in *ClusterStatus) mergeProbedStatus(probed *ClusterStatus) {
+	in.Version = probed.Version
+	in.LastProbeTime = probed.LastProbeTime
+	in.Nodes = probed.Nodes
+	in.Capacity = probed.Capacity
+	for _, condition := range probed.Conditions {
+		meta.SetStatusCondition(&in.Conditions, condition)
+	}
+}
+
+// setCertificateExpiry records the expiry of the client certificate in the
+// status and the synthetic label. The transition time is derived from the
+// expiry so that the cluster stays unchanged between reads.
+func (in *Cluster) setCertificateExpiry(notAfter time.Time) {
+	in.Status.CertNotAfter = &metav1.Time{Time: notAfter}
+	expiringAt := notAfter.Add(-ClusterCertExpiringThreshold)
+	condition := metav1.Condition{
+		Type:               ClusterConditionTypeExpiringSoon,
+		Status:             metav1.ConditionFalse,
+		Reason:             "CertificateValid",
+		Message:            "client certificate expires at " + notAfter.UTC().Format(time.RFC3339),
+		LastTransitionTime: in.GetCreationTimestamp(),
+	}
+	if time.Now().After(expiringAt) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "CertificateExpiringSoon"
+		condition.LastTransitionTime = metav1.NewTime(expiringAt)
+	}
+	meta.SetStatusCondition(&in.Status.Conditions, condition)
+	metav1.SetMetaDataLabel(&in.ObjectMeta, LabelClusterCertExpiring, strconv.FormatBool(condition.Status == metav1.ConditionTrue))
+}
+
+// ProbeClusterStatus probe the status of the cluster through cluster-gateway
+func ProbeClusterStatus(ctx context.Context, name string) *ClusterStatus {
+	ctx, cancel := context.WithTimeout(ctx, ClusterStatusProbeTimeout)
+	defer cancel()
+	now := metav1.Now()
+	status := &ClusterStatus{LastProbeTime: &now}
+	condition := metav1.Condition{
+		Type:               ClusterConditionTypeReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ProbeSucceeded",
+		LastTransitionTime: now,
+	}
+	if err := probeClusterStatus(ctx, name, status); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ProbeFailed"
+		condition.Message = err.Error()
+	}
+	status.Conditions = []metav1.Condition{condition}
+	return status
+}
+
+func probeClusterStatus(ctx context.Context, name string, status *ClusterStatus) error {
+	cfg := clientrest.CopyConfig(singleton.KubeConfig.Get())
+	cfg.Wrap(multicluster.NewTransportWrapper(multicluster.ForCluster(name)))
+	cli, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	bs, err := cli.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return err
+	}
+	info := &version.Info{}
+	if err = json.Unmarshal(bs, info); err != nil {
+		return err
+	}
+	status.Version = info.GitVersion
+	nodes, err := cli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	status.Nodes = len(nodes.Items)
+	status.Capacity = corev1.ResourceList{}
+	for _, node := range nodes.Items {
+		for _, key := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, found := node.Status.Capacity[key]; found {
+				total := status.Capacity[key]
+				total.Add(quantity)
+				status.Capacity[key] = total
+			}
+		}
+	}
+	return nil
+}
+
+func newClusterStatusFromManagedCluster(managedCluster *ocmclusterv1.ManagedCluster) ClusterStatus {
+	status := ClusterStatus{Version: managedCluster.Status.Version.Kubernetes}
+	for _, key := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if quantity, found := managedCluster.Status.Capacity[ocmclusterv1.ResourceName(key)]; found {
+			if status.Capacity == nil {
+				status.Capacity = corev1.ResourceList{}
+			}
+			status.Capacity[key] = quantity.DeepCopy()
+		}
+	}
+	if condition := meta.FindStatusCondition(managedCluster.Status.Conditions, ocmclusterv1.ManagedClusterConditionAvailable); condition != nil {
+		status.Conditions = []metav1.Condition{{
+			Type:               ClusterConditionTypeReachable,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+		}}
+	}
+	return status
+}
+
+// ClusterProbe serves the status subresource of clusters. It is registered
+// through subresource.StatusResource in the apiserver.
+// +kubebuilder:object:generate=false
+type ClusterProbe struct{}
+
+var _ rest.Storage = &ClusterProbe{}
+var _ rest.Getter = &ClusterProbe{}
+
+// New returns a new instance of the resource
+func (in *ClusterProbe) New() runtime.Object {
+	return &Cluster{}
+}
+
+// Destroy .
+func (in *ClusterProbe) Destroy() {}
+
+// Get returns the cluster with its status, which is probed if the cached one
+// is outdated
+func (in *ClusterProbe) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	cluster, err := NewDefaultClusterClient().Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	cluster.LoadStatus(ctx, false)
+	return cluster, nil
+}
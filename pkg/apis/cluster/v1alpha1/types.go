@@ -20,9 +20,11 @@ import (
 	"fmt"
 
 	clusterv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
 	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
 )
 
@@ -32,7 +34,8 @@ type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ClusterSpec `json:"spec,omitempty"`
+	Spec   ClusterSpec   `json:"spec,omitempty"`
+	Status ClusterStatus `json:"status,omitempty"`
 }
 
 // ClusterSpec spec of cluster
@@ -41,6 +44,34 @@ type ClusterSpec struct {
 	Accepted       bool                           `json:"accepted,omitempty"`
 	Endpoint       string                         `json:"endpoint,omitempty"`
 	CredentialType clusterv1alpha1.CredentialType `json:"credential-type,omitempty"`
+	// Credential is only used when creating clusters and will never be returned
+	Credential *ClusterCredential `json:"credential,omitempty"`
+}
+
+// ClusterCredential credential for accessing cluster. It could be derived from
+// kubeconfig or set explicitly. Explicitly set fields override the ones parsed
+// from kubeconfig.
+type ClusterCredential struct {
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	CAData     []byte `json:"ca-data,omitempty"`
+	CertData   []byte `json:"cert-data,omitempty"`
+	KeyData    []byte `json:"key-data,omitempty"`
+	Token      string `json:"token,omitempty"`
+	// Insecure skips the verification of the cluster certificate when
+	// validating the credential, and the CA is not stored so that the cluster
+	// is accessed without verification either. The system roots are used for
+	// validation if no CA is given.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// ClusterStatus status of cluster
+type ClusterStatus struct {
+	Version       string              `json:"version,omitempty"`
+	LastProbeTime *metav1.Time        `json:"last-probe-time,omitempty"`
+	Nodes         int                 `json:"nodes,omitempty"`
+	Capacity      corev1.ResourceList `json:"capacity,omitempty"`
+	CertNotAfter  *metav1.Time        `json:"cert-not-after,omitempty"`
+	Conditions    []metav1.Condition  `json:"conditions,omitempty"`
 }
 
 // ClusterList list for Cluster
@@ -53,6 +84,13 @@ type ClusterList struct {
 }
 
 var _ resource.Object = &Cluster{}
+var _ rest.Getter = &Cluster{}
+var _ rest.Lister = &Cluster{}
+var _ rest.Watcher = &Cluster{}
+var _ rest.Creater = &Cluster{}
+var _ rest.Updater = &Cluster{}
+var _ rest.GracefulDeleter = &Cluster{}
+var _ resource.ObjectWithArbitrarySubResource = &Cluster{}
 
 // GetObjectMeta returns the object meta reference.
 func (in *Cluster) GetObjectMeta() *metav1.ObjectMeta {
@@ -92,6 +130,11 @@ func (in *Cluster) ShortNames() []string {
 	return []string{"vc", "vela-cluster", "vela-clusters"}
 }
 
+// GetArbitrarySubResources returns the list of arbitrary subresources for Cluster
+func (in *Cluster) GetArbitrarySubResources() []resource.ArbitrarySubResource {
+	return []resource.ArbitrarySubResource{&ClusterAlias{}, &ClusterCredentialRotation{}, &ClusterProxy{}}
+}
+
 // GetFullName returns the name with alias
 func (in *Cluster) GetFullName() string {
 	if in.Spec.Alias == "" {
@@ -18,6 +18,19 @@ package v1alpha1
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
 
 	clustergatewayv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
 	clustergatewaycommon "github.com/oam-dev/cluster-gateway/pkg/common"
@@ -25,11 +38,27 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientrest "k8s.io/client-go/rest"
 	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+	ocmclusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kubevela/pkg/util/k8s"
 	"github.com/kubevela/pkg/util/singleton"
 )
 
@@ -197,4 +226,687 @@ var _ = Describe("Test Cluster API", func() {
 		Ω(err).To(Succeed())
 	})
 
+	It("Test Cluster Watch", func() {
+		c := &Cluster{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		sel := labels.SelectorFromSet(map[string]string{"watch": "true"})
+
+		By("Test watch cluster events")
+		w, err := c.Watch(ctx, &metainternalversion.ListOptions{LabelSelector: sel})
+		Ω(err).To(Succeed())
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "watch-cluster",
+				Namespace: StorageNamespace,
+				Labels: map[string]string{
+					clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+					clustergatewaycommon.LabelKeyClusterEndpointType:   string(clustergatewayv1alpha1.ClusterEndpointTypeConst),
+					"watch": "true",
+				},
+			},
+		}
+		Ω(singleton.KubeClient.Get().Create(ctx, secret)).To(Succeed())
+		expectEvent := func(w watch.Interface, eventType watch.EventType, alias string) {
+			var event watch.Event
+			Eventually(w.ResultChan()).WithTimeout(10 * time.Second).Should(Receive(&event))
+			Ω(event.Type).To(Equal(eventType))
+			cluster, ok := event.Object.(*Cluster)
+			Ω(ok).To(BeTrue())
+			Ω(cluster.GetName()).To(Equal("watch-cluster"))
+			Ω(cluster.Spec.Alias).To(Equal(alias))
+		}
+		expectEvent(w, watch.Added, "")
+		secret.SetAnnotations(map[string]string{AnnotationClusterAlias: "alias"})
+		Ω(singleton.KubeClient.Get().Update(ctx, secret)).To(Succeed())
+		expectEvent(w, watch.Modified, "alias")
+		w.Stop()
+
+		By("Test resume watch from resource version")
+		objs, err := c.List(ctx, &metainternalversion.ListOptions{LabelSelector: sel})
+		Ω(err).To(Succeed())
+		clusters, ok := objs.(*ClusterList)
+		Ω(ok).To(BeTrue())
+		Ω(clusters.ResourceVersion).ShouldNot(BeEmpty())
+		w, err = c.Watch(ctx, &metainternalversion.ListOptions{LabelSelector: sel, ResourceVersion: clusters.ResourceVersion})
+		Ω(err).To(Succeed())
+		defer w.Stop()
+		Consistently(w.ResultChan()).WithTimeout(time.Second).ShouldNot(Receive())
+		Ω(singleton.KubeClient.Get().Delete(ctx, secret)).To(Succeed())
+		expectEvent(w, watch.Deleted, "alias")
+
+		_, err = c.Watch(ctx, &metainternalversion.ListOptions{ResourceVersion: "bad"})
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test resume watch from expired resource version")
+		cli, err := client.NewWithWatch(singleton.KubeConfig.Get(), client.Options{Scheme: scheme.Scheme, Mapper: singleton.RESTMapper.Get()})
+		Ω(err).To(Succeed())
+		w, err = NewClusterWatcher(ctx, expiredListClient{WithWatch: cli}, &metainternalversion.ListOptions{ResourceVersion: "1"})
+		Ω(err).To(Succeed())
+		var event watch.Event
+		Eventually(w.ResultChan()).WithTimeout(10 * time.Second).Should(Receive(&event))
+		Ω(event.Type).To(Equal(watch.Error))
+		status, ok := event.Object.(*metav1.Status)
+		Ω(ok).To(BeTrue())
+		Ω(apierrors.IsResourceExpired(&apierrors.StatusError{ErrStatus: *status})).To(BeTrue())
+		Eventually(w.ResultChan()).WithTimeout(10 * time.Second).Should(BeClosed())
+	})
+
+	It("Test Cluster Status", func() {
+		ctx := context.Background()
+		By("Test get cluster without probing")
+		deleteCachedClusterStatus(ClusterLocalName)
+		obj, err := (&Cluster{}).Get(ctx, ClusterLocalName, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Status.LastProbeTime).To(BeNil())
+
+		By("Test probe local cluster through status subresource")
+		obj, err = (&ClusterProbe{}).Get(ctx, ClusterLocalName, nil)
+		Ω(err).To(Succeed())
+		cluster, ok := obj.(*Cluster)
+		Ω(ok).To(BeTrue())
+		Ω(cluster.Status.Version).ShouldNot(BeEmpty())
+		Ω(cluster.Status.LastProbeTime).ShouldNot(BeNil())
+		Ω(cluster.GetReadyStatus()).To(Equal(string(metav1.ConditionTrue)))
+
+		By("Test get cluster with cached status")
+		obj, err = (&Cluster{}).Get(ctx, ClusterLocalName, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Status.LastProbeTime.Time).To(Equal(cluster.Status.LastProbeTime.Time))
+
+		By("Test status of ManagedCluster")
+		mc := &ocmclusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc"}}
+		mc.Status.Version.Kubernetes = "v1.24.0"
+		mc.Status.Capacity = ocmclusterv1.ResourceList{ocmclusterv1.ResourceCPU: resource.MustParse("2")}
+		mc.Status.Conditions = []metav1.Condition{{Type: ocmclusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionFalse, Reason: "Lost"}}
+		cluster, err = NewClusterFromManagedCluster(mc)
+		Ω(err).To(Succeed())
+		Ω(cluster.Status.Version).To(Equal("v1.24.0"))
+		Ω(cluster.Status.Capacity.Cpu().String()).To(Equal("2"))
+		Ω(cluster.GetReadyStatus()).To(Equal(string(metav1.ConditionFalse)))
+	})
+
+	It("Test Cluster Create/Update/Delete", func() {
+		c := &Cluster{}
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+
+		By("Test convert cluster with kubeconfig credential to secret")
+		kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://example.com:6443
+users:
+- name: test
+  user:
+    token: test-token
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+`
+		cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "created", Labels: map[string]string{"key": "value"}}}
+		_, err := cluster.ToSecret()
+		Ω(err).To(Satisfy(IsInvalidClusterCredentialError))
+		cluster.Spec.Alias = "created-alias"
+		cluster.Spec.Credential = &ClusterCredential{Kubeconfig: kubeconfig}
+		secret, err := cluster.ToSecret()
+		Ω(err).To(Succeed())
+		Ω(string(secret.Data["endpoint"])).To(Equal("https://example.com:6443"))
+		Ω(string(secret.Data[v1.ServiceAccountTokenKey])).To(Equal("test-token"))
+		Ω(secret.GetLabels()[clustergatewaycommon.LabelKeyClusterCredentialType]).To(Equal(string(clustergatewayv1alpha1.CredentialTypeServiceAccountToken)))
+
+		By("Test reject kubeconfig reading files")
+		invalid := cluster.DeepCopy()
+		invalid.Spec.Credential = &ClusterCredential{Kubeconfig: strings.Replace(kubeconfig, "token: test-token", "tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token", 1)}
+		_, err = invalid.ToSecret()
+		Ω(err).To(Satisfy(IsInvalidClusterCredentialError))
+		Ω(err.Error()).To(ContainSubstring("tokenFile"))
+
+		By("Test create cluster")
+		obj, err := c.Create(ctx, cluster, nil, nil)
+		Ω(err).To(Succeed())
+		created, ok := obj.(*Cluster)
+		Ω(ok).To(BeTrue())
+		Ω(created.Spec.Alias).To(Equal("created-alias"))
+		Ω(created.Spec.Endpoint).To(Equal("https://example.com:6443"))
+		Ω(created.Spec.Credential).To(BeNil())
+		Ω(created.GetLabels()["key"]).To(Equal("value"))
+		_, err = c.Create(ctx, cluster, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsAlreadyExists))
+		_, err = c.Create(ctx, &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "invalid"}}, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test update cluster")
+		updated := created.DeepCopy()
+		updated.Spec.Alias = "new-alias"
+		updated.SetLabels(map[string]string{"new-key": "new-value"})
+		obj, _, err = c.Update(ctx, "created", rest.DefaultUpdatedObjectInfo(updated), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Spec.Alias).To(Equal("new-alias"))
+		Ω(obj.(*Cluster).GetLabels()).To(SatisfyAll(HaveKeyWithValue("new-key", "new-value"), Not(HaveKey("key"))))
+		Ω(obj.(*Cluster).Spec.CredentialType).To(Equal(clustergatewayv1alpha1.CredentialTypeServiceAccountToken))
+		updated = obj.(*Cluster).DeepCopy()
+		updated.Spec.Endpoint = "https://changed.com"
+		_, _, err = c.Update(ctx, "created", rest.DefaultUpdatedObjectInfo(updated), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+		_, _, err = c.Update(ctx, ClusterLocalName, rest.DefaultUpdatedObjectInfo(NewLocalCluster()), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test delete cluster in use")
+		rt := &unstructured.Unstructured{}
+		rt.SetGroupVersionKind(ResourceTrackerGroupVersionKind)
+		rt.SetName("app-v1-default")
+		Ω(unstructured.SetNestedSlice(rt.Object, []interface{}{map[string]interface{}{
+			"cluster": "created", "kind": "ConfigMap", "apiVersion": "v1", "name": "cm", "namespace": "default",
+		}}, "spec", "managedResources")).To(Succeed())
+		Ω(unstructured.SetNestedField(rt.Object, int64(1), "spec", "applicationGeneration")).To(Succeed())
+		Ω(singleton.KubeClient.Get().Create(ctx, rt)).To(Succeed())
+		_, _, err = c.Delete(ctx, "created", nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsForbidden))
+		Ω(singleton.KubeClient.Get().Delete(ctx, rt)).To(Succeed())
+		setCachedClusterStatus("created", &ClusterStatus{Version: "v1.24.0"})
+		_, deleted, err := c.Delete(ctx, "created", nil, nil)
+		Ω(err).To(Succeed())
+		Ω(deleted).To(BeTrue())
+		Ω(getCachedClusterStatus("created")).To(BeNil())
+		_, err = c.Get(ctx, "created", nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Test Cluster List with field selector and pagination", func() {
+		c := &Cluster{}
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		for i, name := range []string{"page-a", "page-c", "page-e", "page-g"} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: StorageNamespace,
+					Labels: map[string]string{
+						clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+						"page": "true",
+					},
+					Annotations: map[string]string{AnnotationClusterAlias: fmt.Sprintf("alias-%d", i%2)},
+				},
+				Data: map[string][]byte{"endpoint": []byte("https://" + name)},
+			})).To(Succeed())
+		}
+		for _, name := range []string{"page-b", "page-c", "page-d", "page-f"} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &ocmclusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"page": "true"}},
+				Spec: ocmclusterv1.ManagedClusterSpec{
+					ManagedClusterClientConfigs: []ocmclusterv1.ClientConfig{{URL: "https://" + name}},
+				},
+			})).To(Succeed())
+		}
+		sel := labels.SelectorFromSet(map[string]string{"page": "true"})
+
+		By("Test list with field selector")
+		objs, err := c.List(ctx, &metainternalversion.ListOptions{LabelSelector: sel, FieldSelector: fields.OneTermEqualSelector(ClusterFieldAlias, "alias-1")})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterList).Items).To(HaveLen(2))
+		objs, err = c.List(ctx, &metainternalversion.ListOptions{LabelSelector: sel, FieldSelector: fields.OneTermEqualSelector(ClusterFieldCredentialType, string(CredentialTypeOCMManagedCluster))})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterList).Items).To(HaveLen(3))
+		objs, err = c.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector(ClusterFieldMetadataName, "page-c")})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterList).Items).To(HaveLen(1))
+		Ω(objs.(*ClusterList).Items[0].Spec.CredentialType).To(Equal(clustergatewayv1alpha1.CredentialTypeX509Certificate))
+		objs, err = c.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector(ClusterFieldAccepted, "true")})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterList).HasCluster(ClusterLocalName)).To(BeTrue())
+
+		By("Test list with pagination")
+		var names []string
+		options := &metainternalversion.ListOptions{LabelSelector: sel, Limit: 3}
+		for {
+			objs, err = c.List(ctx, options)
+			Ω(err).To(Succeed())
+			clusters := objs.(*ClusterList)
+			Ω(len(clusters.Items) <= 3).To(BeTrue())
+			Ω(clusters.ResourceVersion).ShouldNot(BeEmpty())
+			for _, cluster := range clusters.Items {
+				names = append(names, cluster.Name)
+			}
+			if clusters.Continue == "" {
+				break
+			}
+			options.Continue = clusters.Continue
+		}
+		Ω(names).To(Equal([]string{"page-a", "page-b", "page-c", "page-d", "page-e", "page-f", "page-g"}))
+		objs, err = c.List(ctx, &metainternalversion.ListOptions{LabelSelector: sel})
+		Ω(err).To(Succeed())
+		var unpaginated []string
+		for _, cluster := range objs.(*ClusterList).Items {
+			unpaginated = append(unpaginated, cluster.Name)
+		}
+		Ω(unpaginated).To(Equal(names))
+		_, err = c.List(ctx, &metainternalversion.ListOptions{Limit: 1, Continue: "bad"})
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+	})
+
+	It("Test Cluster Alias", func() {
+		c := &Cluster{}
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		for _, name := range []string{"alias-a", "alias-b"} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: StorageNamespace,
+					Labels: map[string]string{
+						clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+					},
+				},
+				Data: map[string][]byte{"endpoint": []byte("https://" + name)},
+			})).To(Succeed())
+		}
+		Ω(singleton.KubeClient.Get().Create(ctx, &ocmclusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "alias-ocm"},
+			Spec: ocmclusterv1.ManagedClusterSpec{
+				ManagedClusterClientConfigs: []ocmclusterv1.ClientConfig{{URL: "https://alias-ocm"}},
+			},
+		})).To(Succeed())
+
+		By("Test rename alias")
+		sub := &ClusterAlias{}
+		Ω(sub.SubResourceName()).To(Equal(ClusterAliasSubResource))
+		obj, err := sub.Get(ctx, "alias-a", nil)
+		Ω(err).To(Succeed())
+		cluster := obj.(*Cluster)
+		cluster.Spec.Alias = "prod"
+		obj, _, err = sub.Update(ctx, "alias-a", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Spec.Alias).To(Equal("prod"))
+
+		By("Test get cluster by alias")
+		obj, err = c.Get(ctx, "prod", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).GetName()).To(Equal("alias-a"))
+
+		By("Test alias uniqueness")
+		obj, err = sub.Get(ctx, "alias-ocm", nil)
+		Ω(err).To(Succeed())
+		cluster = obj.(*Cluster)
+		cluster.Spec.Alias = "prod"
+		_, _, err = sub.Update(ctx, "alias-ocm", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsConflict))
+		cluster.Spec.Alias = "alias-b"
+		_, _, err = sub.Update(ctx, "alias-ocm", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsConflict))
+		cluster.Spec.Alias = "staging"
+		obj, _, err = sub.Update(ctx, "alias-ocm", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Spec.Alias).To(Equal("staging"))
+		obj, err = c.Get(ctx, "staging", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Cluster).Spec.CredentialType).To(Equal(CredentialTypeOCMManagedCluster))
+
+		By("Test alias not resolved by writes")
+		_, _, err = c.Update(ctx, "staging", rest.DefaultUpdatedObjectInfo(obj), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+		_, _, err = sub.Update(ctx, "staging", rest.DefaultUpdatedObjectInfo(obj), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+		_, _, err = (&ClusterCredentialRotation{}).Update(ctx, "prod", rest.DefaultUpdatedObjectInfo(obj), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+		_, _, err = c.Delete(ctx, "staging", nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+		_, err = c.Get(ctx, "staging", nil)
+		Ω(err).To(Succeed())
+		_, err = c.Get(ctx, "not-exist", nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Test Cluster Credential Rotation", func() {
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		cfg := singleton.KubeConfig.Get()
+		Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rotate",
+				Namespace: StorageNamespace,
+				Labels: map[string]string{
+					clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeServiceAccountToken),
+				},
+			},
+			Data: map[string][]byte{
+				"endpoint":                 []byte(cfg.Host),
+				v1.ServiceAccountRootCAKey: cfg.CAData,
+				v1.ServiceAccountTokenKey:  []byte("old-token"),
+			},
+		})).To(Succeed())
+		sub := &ClusterCredentialRotation{}
+		Ω(sub.SubResourceName()).To(Equal(ClusterCredentialSubResource))
+
+		By("Test rotate with invalid credential")
+		cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "rotate"}}
+		_, _, err := sub.Update(ctx, "rotate", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+		cluster.Spec.Credential = &ClusterCredential{Token: "invalid-token"}
+		_, _, err = sub.Update(ctx, "rotate", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test rotate with valid credential")
+		cluster.Spec.Credential = &ClusterCredential{CertData: cfg.CertData, KeyData: cfg.KeyData}
+		obj, _, err := sub.Update(ctx, "rotate", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		rotated := obj.(*Cluster)
+		Ω(rotated.Spec.CredentialType).To(Equal(clustergatewayv1alpha1.CredentialTypeX509Certificate))
+		Ω(rotated.Spec.Credential).To(BeNil())
+		Ω(rotated.GetAnnotations()).To(SatisfyAll(
+			HaveKey(AnnotationClusterCredentialRotatedAt),
+			HaveKey(AnnotationClusterCredentialExpireAt),
+		))
+		secret := &v1.Secret{}
+		Ω(singleton.KubeClient.Get().Get(ctx, apitypes.NamespacedName{Namespace: StorageNamespace, Name: "rotate"}, secret)).To(Succeed())
+		Ω(secret.Data).ShouldNot(HaveKey(v1.ServiceAccountTokenKey))
+		Ω(secret.Data[v1.TLSCertKey]).To(Equal(cfg.CertData))
+		Ω(secret.Data[v1.ServiceAccountRootCAKey]).To(Equal(cfg.CAData))
+
+		By("Test rotate with insecure credential")
+		cluster.Spec.Credential = &ClusterCredential{CertData: cfg.CertData, KeyData: cfg.KeyData, Insecure: true}
+		_, _, err = sub.Update(ctx, "rotate", rest.DefaultUpdatedObjectInfo(cluster), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(singleton.KubeClient.Get().Get(ctx, apitypes.NamespacedName{Namespace: StorageNamespace, Name: "rotate"}, secret)).To(Succeed())
+		Ω(secret.Data).ShouldNot(HaveKey(v1.ServiceAccountRootCAKey))
+		Ω(secret.Data[v1.TLSCertKey]).To(Equal(cfg.CertData))
+
+		By("Test validate credential against the cluster certificate")
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		}))
+		defer server.Close()
+		caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		Ω(validateClusterCredential(ctx, server.URL, &ClusterCredential{Token: "token"})).ShouldNot(Succeed())
+		Ω(validateClusterCredential(ctx, server.URL, &ClusterCredential{Token: "token", CAData: caData})).To(Succeed())
+		Ω(validateClusterCredential(ctx, server.URL, &ClusterCredential{Token: "token", Insecure: true})).To(Succeed())
+
+		By("Test token expiry")
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1700000000}`))
+		Ω(getTokenExpiry("header." + payload + ".signature").Unix()).To(Equal(int64(1700000000)))
+		Ω(getTokenExpiry("opaque-token")).To(BeNil())
+	})
+
+	It("Test Cluster Certificate Expiry", func() {
+		c := &Cluster{}
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		newCert := func(notAfter time.Time) []byte {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Ω(err).To(Succeed())
+			tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now().Add(-time.Hour), NotAfter: notAfter}
+			der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+			Ω(err).To(Succeed())
+			return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+		for name, notAfter := range map[string]time.Time{
+			"cert-expiring": time.Now().Add(24 * time.Hour),
+			"cert-valid":    time.Now().Add(365 * 24 * time.Hour),
+		} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: StorageNamespace,
+					Labels: map[string]string{
+						clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+						"cert": "true",
+					},
+				},
+				Data: map[string][]byte{"endpoint": []byte("https://" + name), v1.TLSCertKey: newCert(notAfter)},
+			})).To(Succeed())
+		}
+
+		By("Test get certificate expiry")
+		obj, err := c.Get(ctx, "cert-expiring", nil)
+		Ω(err).To(Succeed())
+		cluster := obj.(*Cluster)
+		Ω(cluster.Status.CertNotAfter).ShouldNot(BeNil())
+		Ω(cluster.GetCertExpiry()).ShouldNot(BeEmpty())
+		Ω(meta.IsStatusConditionTrue(cluster.Status.Conditions, ClusterConditionTypeExpiringSoon)).To(BeTrue())
+		Ω(cluster.GetLabels()[LabelClusterCertExpiring]).To(Equal("true"))
+		obj, err = c.Get(ctx, "cert-valid", nil)
+		Ω(err).To(Succeed())
+		Ω(meta.IsStatusConditionFalse(obj.(*Cluster).Status.Conditions, ClusterConditionTypeExpiringSoon)).To(BeTrue())
+
+		By("Test list clusters with expiring certificate")
+		sel := labels.SelectorFromSet(map[string]string{"cert": "true", LabelClusterCertExpiring: "true"})
+		objs, err := c.List(ctx, &metainternalversion.ListOptions{LabelSelector: sel})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterList).Items).To(HaveLen(1))
+		Ω(objs.(*ClusterList).Items[0].GetName()).To(Equal("cert-expiring"))
+	})
+
+	It("Test ClusterSet API", func() {
+		s := &ClusterSet{}
+		By("Test meta info")
+		Ω(s.New()).To(Equal(&ClusterSet{}))
+		Ω(s.NamespaceScoped()).To(BeFalse())
+		Ω(s.ShortNames()).To(ContainElement("vcs"))
+		Ω(s.GetGroupVersionResource().Resource).To(Equal(ClusterSetResource))
+		Ω(s.IsStorageVersion()).To(BeTrue())
+		Ω(s.NewList()).To(Equal(&ClusterSetList{}))
+
+		ctx := context.Background()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		for name, region := range map[string]string{"set-east-1": "east", "set-east-2": "east", "set-west-1": "west"} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: StorageNamespace,
+					Labels: map[string]string{
+						clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+						"region": region,
+					},
+					Annotations: map[string]string{AnnotationClusterAlias: name + "-alias"},
+				},
+				Data: map[string][]byte{"endpoint": []byte("https://" + name)},
+			})).To(Succeed())
+		}
+		Ω(singleton.KubeClient.Get().Create(ctx, &ocmclusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "set-ocm", Labels: map[string]string{LabelOCMClusterSet: "ocm-set"}},
+			Spec: ocmclusterv1.ManagedClusterSpec{
+				ManagedClusterClientConfigs: []ocmclusterv1.ClientConfig{{URL: "https://set-ocm"}},
+			},
+		})).To(Succeed())
+		Ω(singleton.KubeClient.Get().Create(ctx, &ocmclusterv1beta1.ManagedClusterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ocm-set"},
+		})).To(Succeed())
+
+		By("Test create cluster set")
+		clusterSet := &ClusterSet{ObjectMeta: metav1.ObjectMeta{Name: "east", Labels: map[string]string{"env": "prod"}}}
+		clusterSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}}
+		clusterSet.Spec.Clusters = []string{"set-west-1-alias", "not-exist"}
+		obj, err := s.Create(ctx, clusterSet, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*ClusterSet).Status.Clusters).To(Equal([]string{"set-east-1", "set-east-2", "set-west-1"}))
+		_, err = s.Create(ctx, clusterSet, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsAlreadyExists))
+		_, err = s.Create(ctx, &ClusterSet{ObjectMeta: metav1.ObjectMeta{Name: "ocm-set"}}, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsAlreadyExists))
+		_, err = s.Create(ctx, &ClusterSet{ObjectMeta: metav1.ObjectMeta{Name: "fake-ocm", Labels: map[string]string{LabelClusterSetOCMManaged: "true"}}}, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+		cm, err := (&ClusterSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "meta",
+			UID:             "uid",
+			ResourceVersion: "1",
+			OwnerReferences: []metav1.OwnerReference{{Name: "owner"}},
+			Labels:          map[string]string{"env": "prod"},
+		}}).ToConfigMap()
+		Ω(err).To(Succeed())
+		Ω(cm.ObjectMeta).To(Equal(metav1.ObjectMeta{Name: clusterSetConfigMapNamePrefix + "meta", Namespace: StorageNamespace, Labels: map[string]string{"env": "prod", LabelClusterSetStorage: "true"}}))
+		clusterSetFromConfigMap, err := NewClusterSetFromConfigMap(cm)
+		Ω(err).To(Succeed())
+		Ω(clusterSetFromConfigMap.GetLabels()).To(Equal(map[string]string{"env": "prod"}))
+
+		By("Test update cluster set")
+		updated := obj.(*ClusterSet).DeepCopy()
+		updated.Spec.Clusters = nil
+		obj, _, err = s.Update(ctx, "east", rest.DefaultUpdatedObjectInfo(updated), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*ClusterSet).Status.Clusters).To(Equal([]string{"set-east-1", "set-east-2"}))
+		updated = obj.(*ClusterSet).DeepCopy()
+		updated.SetLabels(map[string]string{LabelClusterSetOCMManaged: "true"})
+		_, _, err = s.Update(ctx, "east", rest.DefaultUpdatedObjectInfo(updated), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test get OCM ManagedClusterSet")
+		obj, err = s.Get(ctx, "ocm-set", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*ClusterSet).IsOCMManagedClusterSet()).To(BeTrue())
+		Ω(obj.(*ClusterSet).Status.Clusters).To(Equal([]string{"set-ocm"}))
+		_, _, err = s.Update(ctx, "ocm-set", rest.DefaultUpdatedObjectInfo(obj), nil, nil, false, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+		_, _, err = s.Delete(ctx, "ocm-set", nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test unlabeled configmap is not a cluster set")
+		Ω(singleton.KubeClient.Get().Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterSetConfigMapNamePrefix + "foreign", Namespace: StorageNamespace},
+		})).To(Succeed())
+		_, err = s.Get(ctx, "foreign", nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+
+		By("Test list cluster sets")
+		objs, err := s.List(ctx, nil)
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterSetList).Items).To(HaveLen(2))
+		objs, err = s.List(ctx, &metainternalversion.ListOptions{LabelSelector: labels.SelectorFromSet(map[string]string{"env": "prod"})})
+		Ω(err).To(Succeed())
+		Ω(objs.(*ClusterSetList).Items).To(HaveLen(1))
+		_, err = s.ConvertToTable(ctx, objs, nil)
+		Ω(err).To(Succeed())
+
+		By("Test delete cluster set")
+		_, _, err = s.Delete(ctx, "east", nil, nil)
+		Ω(err).To(Succeed())
+		_, err = s.Get(ctx, "east", nil)
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("Test Cluster Proxy", func() {
+		p := &ClusterProxy{}
+		Ω(p.SubResourceName()).To(Equal(ClusterProxySubResource))
+		Ω(p.New()).To(Equal(&ClusterProxyOptions{}))
+		opts, withPath, pathKey := p.NewConnectOptions()
+		Ω(opts).To(Equal(&ClusterProxyOptions{}))
+		Ω(withPath).To(BeTrue())
+		Ω(p.ConnectMethods()).To(ContainElement("GET"))
+		values := url.Values{pathKey: []string{"/api/v1/namespaces"}}
+		Ω(opts.(*ClusterProxyOptions).ConvertFromUrlValues(&values)).To(Succeed())
+		Ω(opts.(*ClusterProxyOptions).Path).To(Equal("/api/v1/namespaces"))
+
+		By("Test connect without user")
+		_, err := p.Connect(context.Background(), ClusterLocalName, opts, nil)
+		Ω(err).To(Satisfy(apierrors.IsUnauthorized))
+		_, err = p.Connect(context.Background(), ClusterLocalName, &ClusterSet{}, nil)
+		Ω(err).To(Satisfy(apierrors.IsBadRequest))
+
+		By("Test forward request with impersonation")
+		loopback.SetAuthorizer(authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+			return authorizer.DecisionAllow, "", nil
+		}))
+		requests := make(chan *http.Request, 1)
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests <- r.Clone(context.Background())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer backend.Close()
+		kubeConfig := singleton.KubeConfig.Get()
+		singleton.KubeConfig.Set(&clientrest.Config{Host: backend.URL})
+		defer singleton.KubeConfig.Set(kubeConfig)
+		ctx := request.WithUser(context.Background(), &user.DefaultInfo{
+			Name:   "alice",
+			UID:    "alice-uid",
+			Groups: []string{"dev", "system:authenticated"},
+			Extra:  map[string][]string{"scopes": {"view"}},
+		})
+		handler, err := p.Connect(ctx, ClusterLocalName, opts, nil)
+		Ω(err).To(Succeed())
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/apis/prism.oam.dev/v1alpha1/clusters/local/proxy/api/v1/namespaces?limit=1", nil))
+		Ω(rw.Code).To(Equal(http.StatusOK))
+		var req *http.Request
+		Eventually(requests).Should(Receive(&req))
+		Ω(req.URL.Path).To(Equal("/api/v1/namespaces"))
+		Ω(req.URL.RawQuery).To(Equal("limit=1"))
+		Ω(req.Header.Get("Impersonate-User")).To(Equal("alice"))
+		Ω(req.Header.Get("Impersonate-Uid")).To(Equal("alice-uid"))
+		Ω(req.Header.Values("Impersonate-Group")).To(Equal([]string{"dev", "system:authenticated"}))
+		Ω(req.Header.Get("Impersonate-Extra-Scopes")).To(Equal("view"))
+	})
+
+	It("Test Cluster Cache", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		Ω(k8s.EnsureNamespace(ctx, singleton.KubeClient.Get(), StorageNamespace)).To(Succeed())
+		for _, name := range []string{"cache-a", "cache-b"} {
+			Ω(singleton.KubeClient.Get().Create(ctx, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: StorageNamespace,
+					Labels: map[string]string{
+						clustergatewaycommon.LabelKeyClusterCredentialType: string(clustergatewayv1alpha1.CredentialTypeX509Certificate),
+						"cache": "true",
+					},
+					Annotations: map[string]string{AnnotationClusterAlias: name + "-alias"},
+				},
+				Data: map[string][]byte{"endpoint": []byte("https://" + name)},
+			})).To(Succeed())
+		}
+		c := NewClusterCache()
+		Ω(c.Start(ctx.Done(), singleton.KubeConfig.Get())).To(Succeed())
+		cli := NewCachedClusterClient(singleton.KubeClient.Get(), c)
+
+		By("Test list clusters from cache")
+		expected, err := NewClusterClient(singleton.KubeClient.Get()).List(ctx, client.MatchingLabels{"cache": "true"})
+		Ω(err).To(Succeed())
+		clusters, err := cli.List(ctx, client.MatchingLabels{"cache": "true"})
+		Ω(err).To(Succeed())
+		Ω(clusters.Items).To(HaveLen(2))
+		Ω(clusters.Items).To(Equal(expected.Items))
+		clusters, err = cli.List(ctx, client.MatchingLabels{"cache": "true"}, client.Limit(1))
+		Ω(err).To(Succeed())
+		Ω(clusters.Items).To(HaveLen(1))
+		Ω(clusters.Continue).ToNot(BeEmpty())
+		clusters, err = cli.List(ctx, client.MatchingLabels{"cache": "true"}, client.Limit(1), client.Continue(clusters.Continue))
+		Ω(err).To(Succeed())
+		Ω(clusters.Items).To(HaveLen(1))
+		Ω(clusters.Items[0].GetName()).To(Equal("cache-b"))
+
+		By("Test get cluster from cache")
+		cluster, err := cli.Get(ctx, "cache-a-alias")
+		Ω(err).To(Succeed())
+		Ω(cluster.GetName()).To(Equal("cache-a"))
+
+		By("Test read written clusters from cache")
+		cluster.Spec.Alias = "cache-a-renamed"
+		Ω(cli.Update(ctx, cluster)).To(Succeed())
+		cluster, err = cli.Get(ctx, "cache-a")
+		Ω(err).To(Succeed())
+		Ω(cluster.Spec.Alias).To(Equal("cache-a-renamed"))
+		Ω(cli.Delete(ctx, cluster)).To(Succeed())
+		_, err = cli.Get(ctx, "cache-a")
+		Ω(err).To(Satisfy(apierrors.IsNotFound))
+	})
+
 })
+
+type expiredListClient struct {
+	client.WithWatch
+}
+
+func (c expiredListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	o := &client.ListOptions{}
+	o.ApplyOptions(opts)
+	if o.Raw != nil && o.Raw.ResourceVersionMatch == metav1.ResourceVersionMatchExact {
+		return apierrors.NewResourceExpired("too old resource version")
+	}
+	return c.WithWatch.List(ctx, list, opts...)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	ocmclusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// Watch makes a watch on clusters. Events of cluster secrets and OCM
+// ManagedClusters are merged and converted into cluster events.
+func (in *Cluster) Watch(ctx context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	cli, err := client.NewWithWatch(singleton.KubeConfig.Get(), client.Options{
+		Scheme: scheme.Scheme,
+		Mapper: singleton.RESTMapper.Get(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewClusterWatcher(ctx, cli, options)
+}
+
+type clusterWatcher struct {
+	cli             client.WithWatch
+	selector        clusterSelector
+	resourceVersion uint64
+
+	result chan watch.Event
+	stopCh chan struct{}
+	stop   sync.Once
+
+	mu              sync.Mutex
+	secrets         map[string]*corev1.Secret
+	managedClusters map[string]*ocmclusterv1.ManagedCluster
+	clusters        map[string]*Cluster
+}
+
+// NewClusterWatcher create a watcher for clusters. Cluster secrets and OCM
+// ManagedClusters are watched through informers and merged in the same way as
+// ClusterClient.List does. If resourceVersion is set in options, the informers
+// start from the state at that exact resourceVersion, so that clusters changed
+// or deleted since then are sent as MODIFIED or DELETED events. If that state is
+// no longer available, a 410 Gone error event is sent and the watch is stopped,
+// which makes clients relist.
+func NewClusterWatcher(ctx context.Context, cli client.WithWatch, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	w := &clusterWatcher{
+		cli:             cli,
+		result:          make(chan watch.Event),
+		stopCh:          make(chan struct{}),
+		secrets:         map[string]*corev1.Secret{},
+		managedClusters: map[string]*ocmclusterv1.ManagedCluster{},
+		clusters:        map[string]*Cluster{},
+	}
+	if options != nil {
+		w.selector = clusterSelector{Selector: options.LabelSelector, FieldSelector: options.FieldSelector}
+		if rv := options.ResourceVersion; rv != "" {
+			v, err := strconv.ParseUint(rv, 10, 64)
+			if err != nil {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid resource version %s", rv))
+			}
+			w.resourceVersion = v
+		}
+	}
+
+	informers := []cache.Controller{
+		w.newInformer(ctx, &corev1.SecretList{}, &corev1.Secret{},
+			clusterSelector{Selector: w.selector.Selector, FieldSelector: w.selector.FieldSelector, RequireCredentialType: true}),
+	}
+	err := cli.List(ctx, &ocmclusterv1.ManagedClusterList{}, client.Limit(1))
+	switch {
+	case err == nil:
+		informers = append(informers, w.newInformer(ctx, &ocmclusterv1.ManagedClusterList{}, &ocmclusterv1.ManagedCluster{},
+			clusterSelector{Selector: w.selector.Selector, FieldSelector: w.selector.FieldSelector, RequireCredentialType: false, IgnoreNamespace: true}))
+	case !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err):
+		return nil, err
+	}
+	go w.run(ctx, informers)
+	return w, nil
+}
+
+func (w *clusterWatcher) newInformer(ctx context.Context, list client.ObjectList, obj client.Object, options ...client.ListOption) cache.Controller {
+	resuming := w.resourceVersion != 0
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			initial := resuming && opts.Continue == ""
+			if initial {
+				resuming = false
+				opts.ResourceVersion = strconv.FormatUint(w.resourceVersion, 10)
+				opts.ResourceVersionMatch = metav1.ResourceVersionMatchExact
+			}
+			objs := list.DeepCopyObject().(client.ObjectList)
+			err := w.cli.List(ctx, objs, append(options, &client.ListOptions{Raw: &opts})...)
+			if initial && (apierrors.IsResourceExpired(err) || apierrors.IsGone(err)) {
+				w.sendError(apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d", w.resourceVersion)))
+				w.Stop()
+			}
+			return objs, err
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			objs := list.DeepCopyObject().(client.ObjectList)
+			return w.cli.Watch(ctx, objs, append(options, &client.ListOptions{Raw: &opts})...)
+		},
+	}
+	_, informer := cache.NewInformer(lw, obj, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.onUpdate,
+		UpdateFunc: func(_, newObj interface{}) { w.onUpdate(newObj) },
+		DeleteFunc: w.onDelete,
+	})
+	return informer
+}
+
+func (w *clusterWatcher) run(ctx context.Context, informers []cache.Controller) {
+	defer close(w.result)
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+		case <-w.stopCh:
+		}
+	}()
+	w.mu.Lock()
+	w.update(ClusterLocalName, "")
+	w.mu.Unlock()
+	wg := sync.WaitGroup{}
+	for _, informer := range informers {
+		wg.Add(1)
+		go func(informer cache.Controller) {
+			defer wg.Done()
+			informer.Run(w.stopCh)
+		}(informer)
+	}
+	wg.Wait()
+}
+
+func (w *clusterWatcher) onUpdate(obj interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		w.secrets[o.Name] = o
+		w.update(o.Name, o.ResourceVersion)
+	case *ocmclusterv1.ManagedCluster:
+		w.managedClusters[o.Name] = o
+		w.update(o.Name, o.ResourceVersion)
+	}
+}
+
+func (w *clusterWatcher) onDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		delete(w.secrets, o.Name)
+		w.update(o.Name, o.ResourceVersion)
+	case *ocmclusterv1.ManagedCluster:
+		delete(w.managedClusters, o.Name)
+		w.update(o.Name, o.ResourceVersion)
+	}
+}
+
+// update recompute the cluster with the given name and send the event if it
+// changes. The caller must hold the lock.
+func (w *clusterWatcher) update(name string, resourceVersion string) {
+	var cluster *Cluster
+	if name == ClusterLocalName {
+		cluster = NewLocalCluster()
+	} else {
+		var secrets []corev1.Secret
+		var managedClusters []ocmclusterv1.ManagedCluster
+		if secret, found := w.secrets[name]; found {
+			secrets = append(secrets, *secret)
+		}
+		if managedCluster, found := w.managedClusters[name]; found {
+			managedClusters = append(managedClusters, *managedCluster)
+		}
+		if items := mergeClusters(secrets, managedClusters); len(items) > 0 {
+			cluster = &items[0]
+		}
+	}
+	if cluster != nil && !w.selector.Matches(cluster) {
+		cluster = nil
+	}
+
+	prev, found := w.clusters[name]
+	switch {
+	case cluster != nil && !found:
+		w.clusters[name] = cluster
+		if rv, _ := strconv.ParseUint(cluster.ResourceVersion, 10, 64); w.resourceVersion == 0 || rv > w.resourceVersion {
+			w.send(watch.Added, cluster)
+		}
+	case cluster != nil && !equality.Semantic.DeepEqual(prev, cluster):
+		w.clusters[name] = cluster
+		w.send(watch.Modified, cluster)
+	case cluster == nil && found:
+		delete(w.clusters, name)
+		prev = prev.DeepCopy()
+		prev.SetResourceVersion(resourceVersion)
+		w.send(watch.Deleted, prev)
+	}
+}
+
+func (w *clusterWatcher) send(eventType watch.EventType, cluster *Cluster) {
+	select {
+	case w.result <- watch.Event{Type: eventType, Object: cluster.DeepCopy()}:
+	case <-w.stopCh:
+	}
+}
+
+func (w *clusterWatcher) sendError(err *apierrors.StatusError) {
+	select {
+	case w.result <- watch.Event{Type: watch.Error, Object: &err.ErrStatus}:
+	case <-w.stopCh:
+	}
+}
+
+// Stop stops watching. Will close the channel returned by ResultChan() once
+// the underlying informers exit.
+func (w *clusterWatcher) Stop() {
+	w.stop.Do(func() { close(w.stopCh) })
+}
+
+// ResultChan returns the channel of cluster events
+func (w *clusterWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clustergatewayv1alpha1 "github.com/oam-dev/cluster-gateway/pkg/apis/cluster/v1alpha1"
+	clustergatewaycommon "github.com/oam-dev/cluster-gateway/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Create creates a new cluster secret from the credential of the cluster.
+func (in *Cluster) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	cluster, ok := obj.(*Cluster)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a Cluster: %#v", obj))
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	cli := NewDefaultClusterClient()
+	if err := cli.Create(ctx, cluster); err != nil {
+		return nil, err
+	}
+	return cli.Get(ctx, cluster.GetName())
+}
+
+// Update finds a resource in the storage and updates it. Only alias and labels
+// can be updated. The cluster is found by the exact name rather than alias.
+func (in *Cluster) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cli := NewDefaultClusterClient()
+	existing, err := cli.GetByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, existing)
+	if err != nil {
+		return nil, false, err
+	}
+	cluster, ok := obj.(*Cluster)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("not a Cluster: %#v", obj))
+	}
+	if updateValidation != nil {
+		if err = updateValidation(ctx, cluster, existing); err != nil {
+			return nil, false, err
+		}
+	}
+	if cluster.Spec.Endpoint != existing.Spec.Endpoint ||
+		cluster.Spec.CredentialType != existing.Spec.CredentialType ||
+		cluster.Spec.Accepted != existing.Spec.Accepted ||
+		cluster.Spec.Credential != nil {
+		return nil, false, apierrors.NewBadRequest("only alias and labels of cluster can be updated")
+	}
+	if err = cli.Update(ctx, cluster); err != nil {
+		return nil, false, err
+	}
+	obj, err = cli.GetByName(ctx, name)
+	return obj, false, err
+}
+
+// Delete finds a resource in the storage and deletes it. Clusters that are
+// still used by ResourceTrackers cannot be deleted. The cluster is found by the
+// exact name rather than alias.
+func (in *Cluster) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	cli := NewDefaultClusterClient()
+	cluster, err := cli.GetByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if deleteValidation != nil {
+		if err = deleteValidation(ctx, cluster); err != nil {
+			return nil, false, err
+		}
+	}
+	if err = cli.Delete(ctx, cluster); err != nil {
+		return nil, false, err
+	}
+	deleteCachedClusterStatus(cluster.GetName())
+	return cluster, true, nil
+}
+
+// ToSecret convert the cluster with its credential into cluster-gateway secret
+func (in *Cluster) ToSecret() (*corev1.Secret, error) {
+	if in.Spec.Credential == nil {
+		return nil, NewInvalidClusterCredentialError("no credential provided")
+	}
+	endpoint := in.Spec.Endpoint
+	if endpoint == ClusterBlankEndpoint {
+		endpoint = ""
+	}
+	endpoint, cred, err := in.Spec.Credential.resolve(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      in.GetName(),
+			Namespace: StorageNamespace,
+			Labels:    map[string]string{},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"endpoint": []byte(endpoint)},
+	}
+	for k, v := range extractLabels(in.GetLabels()) {
+		secret.Labels[k] = v
+	}
+	if in.Spec.Alias != "" {
+		secret.SetAnnotations(map[string]string{AnnotationClusterAlias: in.Spec.Alias})
+	}
+	cred.applyToSecret(secret)
+	return secret, nil
+}
+
+// resolve returns the endpoint and the credential with fields parsed from
+// kubeconfig. Fields parsed from kubeconfig are only used if not set explicitly.
+func (in *ClusterCredential) resolve(endpoint string) (string, *ClusterCredential, error) {
+	cred := &ClusterCredential{CAData: in.CAData, CertData: in.CertData, KeyData: in.KeyData, Token: in.Token, Insecure: in.Insecure}
+	if in.Kubeconfig != "" {
+		cluster, user, err := parseKubeconfig(in.Kubeconfig)
+		if err != nil {
+			return "", nil, err
+		}
+		if endpoint == "" {
+			endpoint = cluster.Server
+		}
+		if len(cred.CAData) == 0 {
+			cred.CAData = cluster.CertificateAuthorityData
+		}
+		cred.Insecure = cred.Insecure || cluster.InsecureSkipTLSVerify
+		if len(cred.CertData) == 0 && len(cred.KeyData) == 0 {
+			cred.CertData, cred.KeyData = user.ClientCertificateData, user.ClientKeyData
+		}
+		if cred.Token == "" {
+			cred.Token = user.Token
+		}
+	}
+	if endpoint == "" {
+		return "", nil, NewInvalidClusterCredentialError("no endpoint provided")
+	}
+	if cred.getCredentialType() == "" {
+		return "", nil, NewInvalidClusterCredentialError("either client certificate/key or token is required")
+	}
+	return endpoint, cred, nil
+}
+
+// parseKubeconfig returns the cluster and the user of the current context in
+// the kubeconfig. The kubeconfig is resolved on the server side, so only inline
+// data is accepted. File paths, exec and auth-provider plugins are rejected.
+func parseKubeconfig(kubeconfig string) (*clientcmdapi.Cluster, *clientcmdapi.AuthInfo, error) {
+	cfg, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return nil, nil, NewInvalidClusterCredentialError(err.Error())
+	}
+	kubeContext, found := cfg.Contexts[cfg.CurrentContext]
+	if !found {
+		return nil, nil, NewInvalidClusterCredentialError(fmt.Sprintf("context %q not found in kubeconfig", cfg.CurrentContext))
+	}
+	cluster, found := cfg.Clusters[kubeContext.Cluster]
+	if !found {
+		return nil, nil, NewInvalidClusterCredentialError(fmt.Sprintf("cluster %q not found in kubeconfig", kubeContext.Cluster))
+	}
+	user, found := cfg.AuthInfos[kubeContext.AuthInfo]
+	if !found {
+		user = clientcmdapi.NewAuthInfo()
+	}
+	var disallowed []string
+	if cluster.CertificateAuthority != "" {
+		disallowed = append(disallowed, "certificate-authority")
+	}
+	if user.ClientCertificate != "" {
+		disallowed = append(disallowed, "client-certificate")
+	}
+	if user.ClientKey != "" {
+		disallowed = append(disallowed, "client-key")
+	}
+	if user.TokenFile != "" {
+		disallowed = append(disallowed, "tokenFile")
+	}
+	if user.Exec != nil {
+		disallowed = append(disallowed, "exec")
+	}
+	if user.AuthProvider != nil {
+		disallowed = append(disallowed, "auth-provider")
+	}
+	if len(disallowed) > 0 {
+		return nil, nil, NewInvalidClusterCredentialError(fmt.Sprintf("%s in kubeconfig not supported, only inline credentials are allowed", strings.Join(disallowed, ", ")))
+	}
+	return cluster, user, nil
+}
+
+func (in *ClusterCredential) getCredentialType() clustergatewayv1alpha1.CredentialType {
+	switch {
+	case len(in.CertData) > 0 && len(in.KeyData) > 0:
+		return clustergatewayv1alpha1.CredentialTypeX509Certificate
+	case in.Token != "":
+		return clustergatewayv1alpha1.CredentialTypeServiceAccountToken
+	default:
+		return ""
+	}
+}
+
+// applyToSecret writes the resolved credential into the cluster secret and
+// removes the stale credential data. The CA is dropped for insecure credential.
+func (in *ClusterCredential) applyToSecret(secret *corev1.Secret) {
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, corev1.ServiceAccountTokenKey} {
+		delete(secret.Data, key)
+	}
+	// cluster-gateway skips the verification of the cluster certificate if
+	// there is no CA in the secret
+	if in.Insecure {
+		delete(secret.Data, corev1.ServiceAccountRootCAKey)
+	} else if len(in.CAData) > 0 {
+		secret.Data[corev1.ServiceAccountRootCAKey] = in.CAData
+	}
+	credentialType := in.getCredentialType()
+	secret.Labels[clustergatewaycommon.LabelKeyClusterCredentialType] = string(credentialType)
+	switch credentialType {
+	case clustergatewayv1alpha1.CredentialTypeX509Certificate:
+		secret.Data[corev1.TLSCertKey] = in.CertData
+		secret.Data[corev1.TLSPrivateKeyKey] = in.KeyData
+	case clustergatewayv1alpha1.CredentialTypeServiceAccountToken:
+		secret.Data[corev1.ServiceAccountTokenKey] = []byte(in.Token)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+const (
+	// GrafanaRotateTokenSubResource the name of the subresource for rotating the service account token
+	GrafanaRotateTokenSubResource = "rotate-token"
+	// GrafanaServiceAccountNamePrefix the prefix of the default service account name
+	GrafanaServiceAccountNamePrefix = "prism-"
+	// GrafanaServiceAccountDefaultRole the default role of the service account
+	GrafanaServiceAccountDefaultRole = "Admin"
+)
+
+// GrafanaBootstrap exchanges the basic auth credential for a grafana service
+// account token when the grafana is created. Only the token is stored.
+type GrafanaBootstrap struct {
+	// ServiceAccount is the name of the service account, default to prism-<name>.
+	// The service account is created if not exists.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Role is the org role of the service account, default to Admin
+	Role string `json:"role,omitempty"`
+
+	// ServiceAccountID is the id of the service account in grafana
+	ServiceAccountID int64 `json:"serviceAccountID,omitempty"`
+	// TokenID is the id of the token in use
+	TokenID int64 `json:"tokenID,omitempty"`
+}
+
+type grafanaServiceAccount struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+}
+
+type grafanaServiceAccountToken struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// Bootstrap creates the service account and its token with the basic auth
+// credential, and replaces the basic auth credential with the token
+func (in *Grafana) Bootstrap(ctx context.Context) error {
+	bootstrap := in.Spec.Bootstrap
+	if bootstrap == nil {
+		return nil
+	}
+	if in.Spec.Access.BasicAuth == nil || in.Spec.Access.SecretRef != nil {
+		return apierrors.NewBadRequest("inline username/password is required for bootstrapping grafana service account")
+	}
+	if bootstrap.ServiceAccount == "" {
+		bootstrap.ServiceAccount = GrafanaServiceAccountNamePrefix + in.GetName()
+	}
+	if bootstrap.Role == "" {
+		bootstrap.Role = GrafanaServiceAccountDefaultRole
+	}
+	sa, err := in.ensureServiceAccount(ctx, bootstrap.ServiceAccount, bootstrap.Role)
+	if err != nil {
+		return fmt.Errorf("failed to ensure grafana service account %s: %w", bootstrap.ServiceAccount, err)
+	}
+	bootstrap.ServiceAccountID = sa.ID
+	token, err := in.createServiceAccountToken(ctx)
+	if err != nil {
+		return err
+	}
+	bootstrap.TokenID = token.ID
+	in.Spec.Access.Token = pointer.String(token.Key)
+	in.Spec.Access.BasicAuth = nil
+	return nil
+}
+
+// RotateToken creates a new token for the bootstrapped service account with
+// the current token. The old token should be revoked through RevokeToken
+// after the new token is stored.
+func (in *Grafana) RotateToken(ctx context.Context) (oldTokenID int64, err error) {
+	bootstrap := in.Spec.Bootstrap
+	if bootstrap == nil || bootstrap.ServiceAccountID == 0 || in.Spec.Access.Token == nil {
+		return 0, apierrors.NewBadRequest(fmt.Sprintf("grafana %s is not bootstrapped with service account token", in.GetName()))
+	}
+	token, err := in.createServiceAccountToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+	oldTokenID, bootstrap.TokenID = bootstrap.TokenID, token.ID
+	in.Spec.Access.Token = pointer.String(token.Key)
+	return oldTokenID, nil
+}
+
+// RevokeToken deletes the token of the bootstrapped service account
+func (in *Grafana) RevokeToken(ctx context.Context, tokenID int64) error {
+	path := fmt.Sprintf("/api/serviceaccounts/%d/tokens/%d", in.Spec.Bootstrap.ServiceAccountID, tokenID)
+	return in.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+func (in *Grafana) ensureServiceAccount(ctx context.Context, name string, role string) (*grafanaServiceAccount, error) {
+	result := struct {
+		ServiceAccounts []grafanaServiceAccount `json:"serviceAccounts"`
+	}{}
+	if err := in.getJSON(ctx, "/api/serviceaccounts/search?query="+url.QueryEscape(name), &result); err != nil {
+		return nil, err
+	}
+	for _, sa := range result.ServiceAccounts {
+		if sa.Name == name {
+			return &sa, nil
+		}
+	}
+	sa := &grafanaServiceAccount{}
+	return sa, in.doJSON(ctx, http.MethodPost, "/api/serviceaccounts", &grafanaServiceAccount{Name: name, Role: role}, sa)
+}
+
+func (in *Grafana) createServiceAccountToken(ctx context.Context) (*grafanaServiceAccountToken, error) {
+	path := fmt.Sprintf("/api/serviceaccounts/%d/tokens", in.Spec.Bootstrap.ServiceAccountID)
+	token := &grafanaServiceAccountToken{}
+	name := fmt.Sprintf("%s%d", GrafanaServiceAccountNamePrefix, time.Now().UnixNano())
+	if err := in.doJSON(ctx, http.MethodPost, path, &grafanaServiceAccountToken{Name: name}, token); err != nil {
+		return nil, fmt.Errorf("failed to create grafana service account token: %w", err)
+	}
+	return token, nil
+}
+
+// GrafanaTokenRotation the write-only subresource for rotating the service
+// account token of the bootstrapped grafana. The body is ignored.
+// +kubebuilder:object:generate=false
+type GrafanaTokenRotation struct{}
+
+var _ resource.ArbitrarySubResource = &GrafanaTokenRotation{}
+var _ rest.Updater = &GrafanaTokenRotation{}
+
+// SubResourceName returns the name of the subresource
+func (in *GrafanaTokenRotation) SubResourceName() string {
+	return GrafanaRotateTokenSubResource
+}
+
+// New returns a new instance of the resource
+func (in *GrafanaTokenRotation) New() runtime.Object {
+	return &Grafana{}
+}
+
+// Destroy .
+func (in *GrafanaTokenRotation) Destroy() {}
+
+// Update rotates the token. The new token is stored before the old one is revoked.
+func (in *GrafanaTokenRotation) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cli := NewGrafanaClient(singleton.KubeClient.Get())
+	grafana, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	// load the fingerprint key ahead so that the rotated grafana can be redacted
+	if _, err = loadFingerprintKey(ctx, singleton.KubeClient.Get()); err != nil {
+		return nil, false, err
+	}
+	oldTokenID, err := grafana.RotateToken(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	deleteCachedGrafanaStatus(name)
+	if err = cli.Update(ctx, grafana); err != nil {
+		return nil, false, err
+	}
+	if err = grafana.RevokeToken(ctx, oldTokenID); err != nil {
+		klog.Warningf("failed to revoke the old token %d of grafana %s: %v", oldTokenID, name, err)
+	}
+	if err = grafana.Redact(ctx); err != nil {
+		return nil, false, err
+	}
+	return grafana, false, nil
+}
@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubevela/pkg/util/apiserver"
@@ -60,7 +61,14 @@ func (c *grafanaClient) Get(ctx context.Context, name string) (*Grafana, error)
 	if err != nil {
 		return nil, err
 	}
-	return NewGrafanaFromSecret(secret)
+	grafana, err := NewGrafanaFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err = grafana.resolveSecretRef(ctx, c.Client); err != nil {
+		return nil, err
+	}
+	return grafana, nil
 }
 
 func (c *grafanaClient) List(ctx context.Context, options ...client.ListOption) (*GrafanaList, error) {
@@ -76,6 +84,9 @@ func (c *grafanaClient) List(ctx context.Context, options ...client.ListOption)
 		if err != nil {
 			continue
 		}
+		if err = grafana.resolveSecretRef(ctx, c.Client); err != nil {
+			klog.Warningf("failed to resolve credential for grafana %s: %v", grafana.GetName(), err)
+		}
 		grafanaList.Items = append(grafanaList.Items, *grafana)
 	}
 	return grafanaList, nil
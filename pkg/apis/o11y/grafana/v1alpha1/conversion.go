@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -37,14 +40,32 @@ func (in *Grafana) ToSecret() *corev1.Secret {
 		annotations = map[string]string{}
 	}
 	annotations[grafanaSecretEndpointAnnotationKey] = in.Spec.Endpoint
+	if in.Spec.OrgID != 0 {
+		annotations[grafanaSecretOrgIDAnnotationKey] = strconv.FormatInt(in.Spec.OrgID, 10)
+	}
 	secret.SetAnnotations(annotations)
-	if in.Spec.Access.Token != nil {
+	switch {
+	case in.Spec.Access.SecretRef != nil:
+		secret.Data[grafanaSecretRefKey], _ = json.Marshal(in.Spec.Access.SecretRef)
+	case in.Spec.Access.Token != nil:
 		secret.Data[grafanaSecretTokenKey] = []byte(*in.Spec.Access.Token)
-	}
-	if in.Spec.Access.BasicAuth != nil {
+	case in.Spec.Access.BasicAuth != nil:
 		secret.Data[grafanaSecretUsernameKey] = []byte(in.Spec.Access.Username)
 		secret.Data[grafanaSecretPasswordKey] = []byte(in.Spec.Access.Password)
 	}
+	if in.Spec.Bootstrap != nil {
+		secret.Data[grafanaSecretBootstrapKey], _ = json.Marshal(in.Spec.Bootstrap)
+	}
+	if len(in.Spec.Access.CAData) > 0 {
+		secret.Data[grafanaSecretCAKey] = in.Spec.Access.CAData
+	}
+	if len(in.Spec.Access.CertData) > 0 && len(in.Spec.Access.KeyData) > 0 {
+		secret.Data[grafanaSecretCertKey] = in.Spec.Access.CertData
+		secret.Data[grafanaSecretKeyKey] = in.Spec.Access.KeyData
+	}
+	if in.Spec.Access.InsecureSkipVerify {
+		secret.Data[grafanaSecretInsecureKey] = []byte(strconv.FormatBool(true))
+	}
 	return secret
 }
 
@@ -61,6 +82,10 @@ func NewGrafanaFromSecret(secret *corev1.Secret) (*Grafana, error) {
 	if annotations := secret.GetAnnotations(); annotations != nil {
 		grafana.Spec.Endpoint = strings.TrimSpace(annotations[grafanaSecretEndpointAnnotationKey])
 		delete(annotations, grafanaSecretEndpointAnnotationKey)
+		if raw, found := annotations[grafanaSecretOrgIDAnnotationKey]; found {
+			grafana.Spec.OrgID, _ = strconv.ParseInt(raw, 10, 64)
+			delete(annotations, grafanaSecretOrgIDAnnotationKey)
+		}
 		grafana.SetAnnotations(annotations)
 	}
 	if grafana.Spec.Endpoint == "" {
@@ -75,7 +100,27 @@ func NewGrafanaFromSecret(secret *corev1.Secret) (*Grafana, error) {
 			Password: string(secret.Data[grafanaSecretPasswordKey]),
 		}
 	}
-	if grafana.Spec.Access.BasicAuth == nil && grafana.Spec.Access.Token == nil {
+	if secret.Data[grafanaSecretRefKey] != nil {
+		ref := &GrafanaSecretRef{}
+		if err := json.Unmarshal(secret.Data[grafanaSecretRefKey], ref); err != nil {
+			return nil, fmt.Errorf("invalid secretRef in grafana secret: %w", err)
+		}
+		grafana.Spec.Access.SecretRef = ref
+	}
+	if secret.Data[grafanaSecretBootstrapKey] != nil {
+		bootstrap := &GrafanaBootstrap{}
+		if err := json.Unmarshal(secret.Data[grafanaSecretBootstrapKey], bootstrap); err != nil {
+			return nil, fmt.Errorf("invalid bootstrap in grafana secret: %w", err)
+		}
+		grafana.Spec.Bootstrap = bootstrap
+	}
+	grafana.Spec.Access.CAData = secret.Data[grafanaSecretCAKey]
+	if secret.Data[grafanaSecretCertKey] != nil && secret.Data[grafanaSecretKeyKey] != nil {
+		grafana.Spec.Access.CertData = secret.Data[grafanaSecretCertKey]
+		grafana.Spec.Access.KeyData = secret.Data[grafanaSecretKeyKey]
+	}
+	grafana.Spec.Access.InsecureSkipVerify, _ = strconv.ParseBool(string(secret.Data[grafanaSecretInsecureKey]))
+	if !grafana.Spec.Access.hasCredential() {
 		return nil, NewEmptyCredentialGrafanaSecretError()
 	}
 	return grafana, nil
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/singleton"
+
+	"github.com/kubevela/prism/pkg/apis/o11y/config"
+)
+
+const (
+	grafanaFingerprintKeySecretName = "grafana-fingerprint-key"
+	grafanaFingerprintKeyKey        = "key"
+)
+
+// fingerprintKey is the server-side key for computing credential fingerprints,
+// so that the fingerprint cannot be used to guess the credential offline. It
+// is persisted in a secret so that fingerprints are stable across restarts
+// and replicas.
+var fingerprintKey = struct {
+	sync.Mutex
+	key []byte
+}{}
+
+// loadFingerprintKey returns the fingerprint key, which is generated and
+// stored in the observability namespace if not found
+func loadFingerprintKey(ctx context.Context, cli client.Client) ([]byte, error) {
+	fingerprintKey.Lock()
+	defer fingerprintKey.Unlock()
+	if fingerprintKey.key != nil {
+		return fingerprintKey.key, nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: config.ObservabilityNamespace, Name: grafanaFingerprintKeySecretName}
+	err := cli.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		data := make([]byte, sha256.Size)
+		if _, err = rand.Read(data); err != nil {
+			return nil, fmt.Errorf("failed to generate fingerprint key: %w", err)
+		}
+		secret.SetNamespace(key.Namespace)
+		secret.SetName(key.Name)
+		secret.Data = map[string][]byte{grafanaFingerprintKeyKey: data}
+		if err = cli.Create(ctx, secret); apierrors.IsAlreadyExists(err) {
+			// created by another replica
+			err = cli.Get(ctx, key, secret)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fingerprint key: %w", err)
+	}
+	if len(secret.Data[grafanaFingerprintKeyKey]) == 0 {
+		return nil, fmt.Errorf("no fingerprint key found in secret %s/%s", key.Namespace, key.Name)
+	}
+	fingerprintKey.key = secret.Data[grafanaFingerprintKeyKey]
+	return fingerprintKey.key, nil
+}
+
+// Redact removes the secret parts of the credential so that it is never
+// returned to the caller. Only the credential type and the fingerprint of the
+// credential are kept. The CA bundle and the client certificate are public
+// and kept as well.
+func (in *Grafana) Redact(ctx context.Context) error {
+	key, err := loadFingerprintKey(ctx, singleton.KubeClient.Get())
+	if err != nil {
+		return err
+	}
+	access := &in.Spec.Access
+	access.CredentialType = in.GetCredentialType()
+	access.Fingerprint = access.getFingerprint(key)
+	access.Token = nil
+	access.BasicAuth = nil
+	access.KeyData = nil
+	return nil
+}
+
+// getFingerprint returns the HMAC of the stored credential keyed by the
+// fingerprint key
+func (in *AccessCredential) getFingerprint(key []byte) string {
+	h := hmac.New(sha256.New, key)
+	switch {
+	case in.Token != nil:
+		_, _ = h.Write([]byte(*in.Token))
+	case in.BasicAuth != nil:
+		_, _ = h.Write([]byte(in.Username + ":" + in.Password))
+	case in.CertData != nil:
+		_, _ = h.Write(in.CertData)
+		_, _ = h.Write(in.KeyData)
+	default:
+		return ""
+	}
+	return "hmac-sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// hasCredential checks if any credential is set, otherwise the grafana
+// cannot be stored
+func (in *AccessCredential) hasCredential() bool {
+	return in.Token != nil || in.BasicAuth != nil || in.SecretRef != nil || (len(in.CertData) > 0 && len(in.KeyData) > 0)
+}
+
+// restoreRedacted keeps the stored credential if the credential is left
+// redacted, i.e. neither token, basic auth nor client key is set. The client
+// key is only kept if the client certificate is not changed. Credentials
+// resolved from the secretRef are not kept. It returns whether any stored
+// credential is kept.
+func (in *AccessCredential) restoreRedacted(stored *AccessCredential) bool {
+	in.CredentialType, in.Fingerprint = "", ""
+	if in.Token != nil || in.BasicAuth != nil || len(in.KeyData) > 0 {
+		return false
+	}
+	if stored.SecretRef == nil {
+		in.Token = stored.Token
+		in.BasicAuth = stored.BasicAuth
+	}
+	if bytes.Equal(in.CertData, stored.CertData) {
+		in.KeyData = stored.KeyData
+	}
+	return in.Token != nil || in.BasicAuth != nil || len(in.KeyData) > 0
+}
@@ -43,10 +43,21 @@ func NewEmptyEndpointGrafanaSecretError() error {
 type emptyCredentialGrafanaSecretError struct{}
 
 func (e emptyCredentialGrafanaSecretError) Error() string {
-	return fmt.Sprintf("secret is not a valid grafana secret, no credential found (token or username/password should be set)")
+	return fmt.Sprintf("secret is not a valid grafana secret, no credential found (token, username/password, secretRef or client certificate should be set)")
 }
 
 // NewEmptyCredentialGrafanaSecretError create an invalid grafana secret error due to no credential found
 func NewEmptyCredentialGrafanaSecretError() error {
 	return emptyCredentialGrafanaSecretError{}
 }
+
+type emptyCredentialGrafanaError struct{}
+
+func (e emptyCredentialGrafanaError) Error() string {
+	return "no credential found in grafana (token, username/password, secretRef or client certificate should be set)"
+}
+
+// NewEmptyCredentialGrafanaError create an invalid grafana error due to no credential found
+func NewEmptyCredentialGrafanaError() error {
+	return emptyCredentialGrafanaError{}
+}
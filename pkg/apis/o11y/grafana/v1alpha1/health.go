@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+const (
+	// GrafanaHealthSubResource the name of the subresource for checking the
+	// connectivity of grafana
+	GrafanaHealthSubResource = "health"
+	// GrafanaDatabaseOK the database status reported by healthy grafana
+	GrafanaDatabaseOK = "ok"
+)
+
+var (
+	// GrafanaHealthCheckTimeout the timeout for checking the health of grafana
+	GrafanaHealthCheckTimeout = 10 * time.Second
+)
+
+// GrafanaHealth the result of checking the connectivity of grafana
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type GrafanaHealth struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Version is the version of grafana
+	Version string `json:"version,omitempty"`
+	// Commit is the commit of grafana
+	Commit string `json:"commit,omitempty"`
+	// Database is the database status of grafana
+	Database string `json:"database,omitempty"`
+	// Authenticated indicates whether the stored credential is accepted
+	Authenticated bool `json:"authenticated"`
+	// User is the login of the user if the credential belongs to a user
+	User string `json:"user,omitempty"`
+	// OrgID is the id of the effective org
+	OrgID int64 `json:"orgId,omitempty"`
+	// OrgName is the name of the effective org
+	OrgName string `json:"orgName,omitempty"`
+	// Role is the role of the user in the effective org
+	Role string `json:"role,omitempty"`
+	// Message is the reason if the check fails
+	Message string `json:"message,omitempty"`
+}
+
+// GrafanaHealthCheck the subresource for checking the connectivity of grafana
+// +kubebuilder:object:generate=false
+type GrafanaHealthCheck struct{}
+
+var _ resource.ArbitrarySubResource = &GrafanaHealthCheck{}
+var _ rest.Getter = &GrafanaHealthCheck{}
+
+// SubResourceName returns the name of the subresource
+func (in *GrafanaHealthCheck) SubResourceName() string {
+	return GrafanaHealthSubResource
+}
+
+// New returns a new instance of the resource
+func (in *GrafanaHealthCheck) New() runtime.Object {
+	return &GrafanaHealth{}
+}
+
+// Destroy .
+func (in *GrafanaHealthCheck) Destroy() {}
+
+// Get checks the connectivity of the grafana with the stored credential
+func (in *GrafanaHealthCheck) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	grafana, err := NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return grafana.CheckHealth(ctx), nil
+}
+
+// Healthy returns true if grafana is reachable, the database is ok and the
+// credential is accepted
+func (in *GrafanaHealth) Healthy() bool {
+	return in.Database == GrafanaDatabaseOK && in.Authenticated
+}
+
+// GetMessage returns the reason why grafana is not healthy
+func (in *GrafanaHealth) GetMessage() string {
+	switch {
+	case in.Message != "":
+		return in.Message
+	case in.Database != GrafanaDatabaseOK:
+		return fmt.Sprintf("database status is %s", in.Database)
+	case !in.Authenticated:
+		return "credential is not accepted"
+	}
+	return ""
+}
+
+// CheckHealth calls /api/health for the status of grafana, then /api/user or
+// /api/org to check the credential and find the effective org and role
+func (in *Grafana) CheckHealth(ctx context.Context) *GrafanaHealth {
+	ctx, cancel := context.WithTimeout(ctx, GrafanaHealthCheckTimeout)
+	defer cancel()
+	health := &GrafanaHealth{}
+	health.SetGroupVersionKind(GrafanaHealthGroupVersionKind)
+	if err := in.checkHealth(ctx, health); err != nil {
+		health.Message = err.Error()
+	}
+	return health
+}
+
+func (in *Grafana) checkHealth(ctx context.Context, health *GrafanaHealth) error {
+	status := struct {
+		Version  string `json:"version"`
+		Commit   string `json:"commit"`
+		Database string `json:"database"`
+	}{}
+	if err := in.getJSON(ctx, "/api/health", &status); err != nil {
+		return err
+	}
+	health.Version, health.Commit, health.Database = status.Version, status.Commit, status.Database
+
+	// tokens of service accounts or api keys are not users
+	user := struct {
+		Login string `json:"login"`
+		OrgID int64  `json:"orgId"`
+	}{}
+	if err := in.getJSON(ctx, "/api/user", &user); err == nil {
+		health.User = user.Login
+	}
+	org := struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}{}
+	if err := in.getJSON(ctx, "/api/org", &org); err != nil {
+		return err
+	}
+	health.Authenticated = true
+	health.OrgID, health.OrgName = org.ID, org.Name
+	if health.User != "" {
+		var orgs []struct {
+			OrgID int64  `json:"orgId"`
+			Role  string `json:"role"`
+		}
+		if err := in.getJSON(ctx, "/api/user/orgs", &orgs); err == nil {
+			for _, o := range orgs {
+				if o.OrgID == org.ID {
+					health.Role = o.Role
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (in *Grafana) getJSON(ctx context.Context, path string, obj interface{}) error {
+	return in.doJSON(ctx, http.MethodGet, path, nil, obj)
+}
+
+// doJSON sends the body as json and decodes the response into obj if it is
+// not nil
+func (in *Grafana) doJSON(ctx context.Context, method string, path string, body interface{}, obj interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(bs)
+	}
+	bs, code, err := in.DoRequest(ctx, method, path, reader)
+	if err != nil {
+		return err
+	}
+	if code < http.StatusOK || code >= http.StatusMultipleChoices {
+		return fmt.Errorf("request %s failed, code: %d, detail: %s", path, code, bs)
+	}
+	if obj == nil {
+		return nil
+	}
+	return json.Unmarshal(bs, obj)
+}
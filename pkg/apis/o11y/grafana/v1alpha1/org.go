@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+const (
+	// GrafanaOrgIDLabelKey the label or annotation key for selecting the org
+	// of grafana subresources, which overrides the default org of grafana
+	GrafanaOrgIDLabelKey = "o11y.oam.dev/grafana-org-id"
+
+	grafanaOrgIDHeader = "X-Grafana-Org-Id"
+)
+
+// GetOrgID returns the org id set in the label or annotation of the object.
+// 0 is returned if not set.
+func GetOrgID(obj metav1.Object) (int64, error) {
+	raw, found := obj.GetLabels()[GrafanaOrgIDLabelKey]
+	if !found {
+		raw, found = obj.GetAnnotations()[GrafanaOrgIDLabelKey]
+	}
+	if !found {
+		return 0, nil
+	}
+	orgID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s: %w", GrafanaOrgIDLabelKey, raw, err)
+	}
+	return orgID, nil
+}
+
+// SetOrgID sets the org id label of the object if the org id is not 0
+func SetOrgID(obj metav1.Object, orgID int64) {
+	if orgID == 0 {
+		return
+	}
+	_labels := obj.GetLabels()
+	if _labels == nil {
+		_labels = map[string]string{}
+	}
+	_labels[GrafanaOrgIDLabelKey] = strconv.FormatInt(orgID, 10)
+	obj.SetLabels(_labels)
+}
+
+// GetOrgIDFromLabelSelector returns the org id required by the label
+// selector. 0 is returned if not required.
+func GetOrgIDFromLabelSelector(sel labels.Selector) int64 {
+	if sel == nil {
+		return 0
+	}
+	raw, found := sel.RequiresExactMatch(GrafanaOrgIDLabelKey)
+	if !found {
+		return 0
+	}
+	orgID, _ := strconv.ParseInt(raw, 10, 64)
+	return orgID
+}
+
+// NewOrgParentResourceName returns the parent resource name of grafana
+// subresources in the org, i.e. <grafana>@<org id>. The name of grafana is
+// returned if the org id is 0.
+func NewOrgParentResourceName(grafanaName string, orgID int64) string {
+	if orgID == 0 {
+		return grafanaName
+	}
+	return grafanaName + subresource.CompoundNameSeparator + strconv.FormatInt(orgID, 10)
+}
+
+// ParseOrgParentResourceName splits the parent resource name into the name of
+// grafana and the org id. 0 is returned if the org is not set.
+func ParseOrgParentResourceName(name string) (string, int64, error) {
+	grafanaName, raw, found := strings.Cut(name, subresource.CompoundNameSeparator)
+	if !found {
+		return name, 0, nil
+	}
+	orgID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || orgID <= 0 {
+		return "", 0, fmt.Errorf("invalid org id %s in name %s", raw, name)
+	}
+	return grafanaName, orgID, nil
+}
+
+// ResolveOrgID returns the name of grafana and the org of the subresource. The
+// org is set either in the name, as <uid>@<grafana>@<org id>, or in the label
+// or annotation of the object, and both must match if set.
+func ResolveOrgID(name *subresource.CompoundName, obj metav1.Object) (string, int64, error) {
+	grafanaName, orgID, err := ParseOrgParentResourceName(name.ParentResourceName)
+	if err != nil {
+		return "", 0, err
+	}
+	objOrgID, err := GetOrgID(obj)
+	if err != nil {
+		return "", 0, err
+	}
+	if orgID != 0 && objOrgID != 0 && orgID != objOrgID {
+		return "", 0, fmt.Errorf("org id %d in name mismatches org id %d in %s", orgID, objOrgID, GrafanaOrgIDLabelKey)
+	}
+	if orgID == 0 {
+		orgID = objOrgID
+	}
+	return grafanaName, orgID, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,20 +36,34 @@ const (
 	GrafanaCredentialTypeBasicAuth GrafanaCredentialType = "BasicAuth"
 	// GrafanaCredentialTypeBearerToken bearer token
 	GrafanaCredentialTypeBearerToken GrafanaCredentialType = "BearerToken"
+	// GrafanaCredentialTypeClientCertificate client certificate
+	GrafanaCredentialTypeClientCertificate GrafanaCredentialType = "ClientCertificate"
 )
 
 // GetCredentialType .
 func (in *Grafana) GetCredentialType() GrafanaCredentialType {
 	switch {
+	case in.Spec.Access.CredentialType != "":
+		return in.Spec.Access.CredentialType
 	case in.Spec.Access.Token != nil:
 		return GrafanaCredentialTypeBearerToken
 	case in.Spec.Access.BasicAuth != nil:
 		return GrafanaCredentialTypeBasicAuth
+	case in.Spec.Access.CertData != nil:
+		return GrafanaCredentialTypeClientCertificate
 	default:
 		return GrafanaCredentialTypeNotAvailable
 	}
 }
 
+// GetLastContactTime returns the last time the Grafana is successfully reached
+func (in *Grafana) GetLastContactTime() string {
+	if in.Status.LastContactTime == nil {
+		return ""
+	}
+	return in.Status.LastContactTime.UTC().Format(time.RFC3339)
+}
+
 // ConvertToTable convert resource to table
 func (in *Grafana) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
 	switch obj := object.(type) {
@@ -66,6 +81,10 @@ var (
 		{Name: "Name", Type: "string", Format: "name", Description: "the name of the Grafana"},
 		{Name: "Endpoint", Type: "string", Description: "the endpoint"},
 		{Name: "Credential_Type", Type: "string", Description: "the credential type"},
+		{Name: "Ready", Type: "string", Description: "the reachability of the Grafana"},
+		{Name: "Version", Type: "string", Description: "the version of the Grafana"},
+		{Name: "Org", Type: "string", Description: "the effective org of the Grafana"},
+		{Name: "Last_Contact", Type: "string", Description: "the last time the Grafana is successfully reached", Priority: 10},
 		{Name: "Labels", Type: "string", Description: "the labels of the Grafana", Priority: 10},
 		{Name: "Creation_Timestamp", Type: "dateTime", Description: "the creation timestamp of the Grafana", Priority: 10},
 	}
@@ -100,6 +119,10 @@ func printGrafanaRow(c *Grafana) metav1.TableRow {
 		c.Name,
 		c.Spec.Endpoint,
 		c.GetCredentialType(),
+		c.GetReadyStatus(),
+		c.Status.Version,
+		c.Status.OrgName,
+		c.GetLastContactTime(),
 		strings.Join(labels, ","),
 		c.GetCreationTimestamp())
 	return row
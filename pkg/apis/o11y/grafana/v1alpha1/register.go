@@ -44,6 +44,7 @@ var AddToScheme = func(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&Grafana{},
 		&GrafanaList{},
+		&GrafanaHealth{},
 	)
 	return nil
 }
@@ -60,4 +61,9 @@ var (
 	GrafanaGroupResource = schema.GroupResource{Group: Group, Resource: GrafanaResource}
 	// GrafanaGroupVersionKind GroupVersionKind for Grafana
 	GrafanaGroupVersionKind = GroupVersion.WithKind(GrafanaKind)
+
+	// GrafanaHealthKind kind name for GrafanaHealth
+	GrafanaHealthKind = "GrafanaHealth"
+	// GrafanaHealthGroupVersionKind GroupVersionKind for GrafanaHealth
+	GrafanaHealthGroupVersionKind = GroupVersion.WithKind(GrafanaHealthKind)
 )
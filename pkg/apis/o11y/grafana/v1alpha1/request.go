@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -44,7 +45,14 @@ func (in *Grafana) DoRequest(ctx context.Context, method string, path string, bo
 		req.SetBasicAuth(in.Spec.Access.Username, in.Spec.Access.Password)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	if in.Spec.OrgID != 0 {
+		req.Header.Set(grafanaOrgIDHeader, strconv.FormatInt(in.Spec.OrgID, 10))
+	}
+	cli, err := in.GetHTTPClient()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	resp, err := cli.Do(req)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -58,6 +66,7 @@ func (in *Grafana) DoRequest(ctx context.Context, method string, path string, bo
 type GrafanaSubResourceRequest struct {
 	resourceName *subresource.CompoundName
 	subResource  resource.Object
+	orgID        int64
 
 	method    string
 	pathFunc  func() (string, error)
@@ -73,6 +82,13 @@ func NewGrafanaSubResourceRequest(subResource resource.Object, name string) *Gra
 	}
 }
 
+// WithOrgID sets the org of the request, which overrides the org set in the
+// name or the subresource object and the default org of grafana
+func (in *GrafanaSubResourceRequest) WithOrgID(orgID int64) *GrafanaSubResourceRequest {
+	in.orgID = orgID
+	return in
+}
+
 func (in *GrafanaSubResourceRequest) WithMethod(method string) *GrafanaSubResourceRequest {
 	in.method = method
 	return in
@@ -94,10 +110,20 @@ func (in *GrafanaSubResourceRequest) WithOnSuccess(onSuccess func(respBody []byt
 }
 
 func (in *GrafanaSubResourceRequest) Do(ctx context.Context, cli GrafanaClient) error {
-	parent, err := cli.Get(ctx, in.resourceName.ParentResourceName)
+	grafanaName, orgID, err := ResolveOrgID(in.resourceName, in.subResource.GetObjectMeta())
+	if err != nil {
+		return errors.NewBadRequest(err.Error())
+	}
+	if in.orgID != 0 {
+		orgID = in.orgID
+	}
+	parent, err := cli.Get(ctx, grafanaName)
 	if err != nil {
 		return err
 	}
+	if orgID != 0 {
+		parent.Spec.OrgID = orgID
+	}
 
 	var path string
 	if in.pathFunc != nil {
@@ -121,14 +147,19 @@ func (in *GrafanaSubResourceRequest) Do(ctx context.Context, cli GrafanaClient)
 	switch statusCode {
 	case http.StatusOK:
 		if in.onSuccess != nil {
-			return in.onSuccess(respBody)
+			if err = in.onSuccess(respBody); err != nil {
+				return err
+			}
 		}
+		SetOrgID(in.subResource.GetObjectMeta(), orgID)
 	case http.StatusUnauthorized:
 		return errors.NewUnauthorized(string(respBody))
 	case http.StatusForbidden:
 		return errors.NewForbidden(in.subResource.GetGroupVersionResource().GroupResource(), in.resourceName.String(), fmt.Errorf(string(respBody)))
 	case http.StatusNotFound:
 		return errors.NewNotFound(in.subResource.GetGroupVersionResource().GroupResource(), in.resourceName.String())
+	case http.StatusConflict:
+		return errors.NewConflict(in.subResource.GetGroupVersionResource().GroupResource(), in.resourceName.String(), fmt.Errorf(string(respBody)))
 	case http.StatusPreconditionFailed:
 		return errors.NewBadRequest(string(respBody))
 	default:
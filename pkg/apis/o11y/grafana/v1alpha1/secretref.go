@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GrafanaSecretRef references the credential in an existing secret. The
+// credential is read from the secret every time the grafana is accessed and
+// is never copied.
+type GrafanaSecretRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Keys defines the keys of the credential in the referenced secret
+	Keys GrafanaSecretRefKeys `json:"keys,omitempty"`
+}
+
+// GrafanaSecretRefKeys defines the keys of the credential in the referenced
+// secret. The token is used if found, otherwise the username and password.
+type GrafanaSecretRefKeys struct {
+	// Username is the key of the username, default to username
+	Username string `json:"username,omitempty"`
+	// Password is the key of the password, default to password
+	Password string `json:"password,omitempty"`
+	// Token is the key of the token, default to token
+	Token string `json:"token,omitempty"`
+}
+
+func (in *GrafanaSecretRef) getKeys() GrafanaSecretRefKeys {
+	keys := in.Keys
+	if keys.Username == "" {
+		keys.Username = grafanaSecretUsernameKey
+	}
+	if keys.Password == "" {
+		keys.Password = grafanaSecretPasswordKey
+	}
+	if keys.Token == "" {
+		keys.Token = grafanaSecretTokenKey
+	}
+	return keys
+}
+
+// validate checks the secretRef and whether the caller is allowed to read the
+// referenced secret, as the credential will be sent to the grafana endpoint
+// set by the caller. The reference is forbidden if the caller cannot be
+// checked.
+func (in *GrafanaSecretRef) validate(ctx context.Context) error {
+	if in == nil {
+		return nil
+	}
+	if in.Namespace == "" || in.Name == "" {
+		return apierrors.NewBadRequest("namespace and name must be set in secretRef")
+	}
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewForbidden(corev1.Resource("secrets"), in.Name, fmt.Errorf("cannot reference secret %s/%s: no user found in request", in.Namespace, in.Name))
+	}
+	authz := loopback.GetAuthorizer()
+	if authz == nil {
+		return apierrors.NewForbidden(corev1.Resource("secrets"), in.Name, fmt.Errorf("cannot reference secret %s/%s: authorizer is not available", in.Namespace, in.Name))
+	}
+	decision, reason, err := authz.Authorize(ctx, authorizer.AttributesRecord{
+		User:            user,
+		Verb:            "get",
+		APIVersion:      corev1.SchemeGroupVersion.Version,
+		Resource:        "secrets",
+		Namespace:       in.Namespace,
+		Name:            in.Name,
+		ResourceRequest: true,
+	})
+	if err != nil {
+		return err
+	}
+	if decision != authorizer.DecisionAllow {
+		return apierrors.NewForbidden(corev1.Resource("secrets"), in.Name, fmt.Errorf("cannot reference secret %s/%s: %s", in.Namespace, in.Name, reason))
+	}
+	return nil
+}
+
+// resolveSecretRef loads the credential from the referenced secret
+func (in *Grafana) resolveSecretRef(ctx context.Context, cli client.Client) error {
+	ref := in.Spec.Access.SecretRef
+	if ref == nil {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return fmt.Errorf("failed to get referenced secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	keys := ref.getKeys()
+	in.Spec.Access.Token, in.Spec.Access.BasicAuth = nil, nil
+	switch {
+	case secret.Data[keys.Token] != nil:
+		in.Spec.Access.Token = pointer.String(string(secret.Data[keys.Token]))
+	case secret.Data[keys.Username] != nil && secret.Data[keys.Password] != nil:
+		in.Spec.Access.BasicAuth = &BasicAuth{
+			Username: string(secret.Data[keys.Username]),
+			Password: string(secret.Data[keys.Password]),
+		}
+	default:
+		return fmt.Errorf("no credential found in referenced secret %s/%s (%s or %s/%s should be set)", ref.Namespace, ref.Name, keys.Token, keys.Username, keys.Password)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+const (
+	// GrafanaConditionTypeReady identifies whether grafana is reachable with the stored credential
+	GrafanaConditionTypeReady = "Ready"
+)
+
+var (
+	// GrafanaStatusCacheTTL the duration for reusing the last probed grafana status
+	GrafanaStatusCacheTTL = time.Minute
+)
+
+// GrafanaStatus status of grafana
+type GrafanaStatus struct {
+	// Version is the version of grafana
+	Version string `json:"version,omitempty"`
+	// OrgName is the name of the effective org
+	OrgName string `json:"orgName,omitempty"`
+	// LastContactTime is the last time grafana is successfully reached
+	LastContactTime *metav1.Time `json:"lastContactTime,omitempty"`
+	// LastProbeTime is the last time grafana is probed
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// Conditions are the conditions of grafana
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+var grafanaStatusCache = struct {
+	sync.RWMutex
+	items map[string]*GrafanaStatus
+}{items: map[string]*GrafanaStatus{}}
+
+func getCachedGrafanaStatus(name string) *GrafanaStatus {
+	grafanaStatusCache.RLock()
+	defer grafanaStatusCache.RUnlock()
+	return grafanaStatusCache.items[name].DeepCopy()
+}
+
+func setCachedGrafanaStatus(name string, status *GrafanaStatus) {
+	grafanaStatusCache.Lock()
+	defer grafanaStatusCache.Unlock()
+	grafanaStatusCache.items[name] = status.DeepCopy()
+}
+
+func deleteCachedGrafanaStatus(name string) {
+	grafanaStatusCache.Lock()
+	defer grafanaStatusCache.Unlock()
+	delete(grafanaStatusCache.items, name)
+}
+
+// LoadStatus fill the status of grafana. Grafana is probed through the same
+// requests as the health subresource if the cached status is outdated or
+// force is set.
+func (in *Grafana) LoadStatus(ctx context.Context, force bool) {
+	status := getCachedGrafanaStatus(in.Name)
+	if force || status == nil || status.LastProbeTime == nil || time.Since(status.LastProbeTime.Time) > GrafanaStatusCacheTTL {
+		probed := in.ProbeStatus(ctx)
+		if status != nil {
+			if probed.LastContactTime == nil {
+				probed.LastContactTime = status.LastContactTime
+			}
+			conditions := status.Conditions
+			for _, condition := range probed.Conditions {
+				meta.SetStatusCondition(&conditions, condition)
+			}
+			probed.Conditions = conditions
+		}
+		status = probed
+		setCachedGrafanaStatus(in.Name, status)
+	}
+	in.Status = *status
+}
+
+// loadCachedStatus fill the status of grafana with the last probed one
+func (in *Grafana) loadCachedStatus() {
+	if status := getCachedGrafanaStatus(in.Name); status != nil {
+		in.Status = *status
+	}
+}
+
+// ProbeStatus probe the status of grafana
+func (in *Grafana) ProbeStatus(ctx context.Context) *GrafanaStatus {
+	health := in.CheckHealth(ctx)
+	now := metav1.Now()
+	status := &GrafanaStatus{Version: health.Version, OrgName: health.OrgName, LastProbeTime: &now}
+	condition := metav1.Condition{
+		Type:               GrafanaConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ProbeSucceeded",
+		LastTransitionTime: now,
+	}
+	if health.Healthy() {
+		status.LastContactTime = &now
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ProbeFailed"
+		condition.Message = health.GetMessage()
+	}
+	status.Conditions = []metav1.Condition{condition}
+	return status
+}
+
+// GetReadyStatus returns the status of the ready condition
+func (in *Grafana) GetReadyStatus() string {
+	if condition := meta.FindStatusCondition(in.Status.Conditions, GrafanaConditionTypeReady); condition != nil {
+		return string(condition.Status)
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// GrafanaProbe serves the status subresource of grafana. It is registered
+// through subresource.StatusResource in the apiserver.
+// +kubebuilder:object:generate=false
+type GrafanaProbe struct{}
+
+var _ rest.Storage = &GrafanaProbe{}
+var _ rest.Getter = &GrafanaProbe{}
+
+// New returns a new instance of the resource
+func (in *GrafanaProbe) New() runtime.Object {
+	return &Grafana{}
+}
+
+// Destroy .
+func (in *GrafanaProbe) Destroy() {}
+
+// Get returns grafana with its status, which is probed if the cached one is
+// outdated
+func (in *GrafanaProbe) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	grafana, err := NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	grafana.LoadStatus(ctx, false)
+	if err = grafana.Redact(ctx); err != nil {
+		return nil, err
+	}
+	return grafana, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+type cachedHTTPClient struct {
+	resourceVersion string
+	client          *http.Client
+}
+
+var grafanaHTTPClientCache = struct {
+	sync.Mutex
+	items map[string]*cachedHTTPClient
+}{items: map[string]*cachedHTTPClient{}}
+
+// GetHTTPClient returns the http client for accessing grafana. Grafana without
+// TLS options uses the default client. Otherwise, the client is built from the
+// TLS options and cached by the resourceVersion of the backing secret.
+func (in *Grafana) GetHTTPClient() (*http.Client, error) {
+	if !in.Spec.Access.hasTLSOptions() {
+		return http.DefaultClient, nil
+	}
+	rv := in.GetResourceVersion()
+	if rv == "" {
+		// the grafana is not persisted yet, e.g. dry-run
+		return in.newHTTPClient()
+	}
+	grafanaHTTPClientCache.Lock()
+	defer grafanaHTTPClientCache.Unlock()
+	if cached, found := grafanaHTTPClientCache.items[in.Name]; found {
+		if cached.resourceVersion == rv {
+			return cached.client, nil
+		}
+		cached.client.CloseIdleConnections()
+		delete(grafanaHTTPClientCache.items, in.Name)
+	}
+	cli, err := in.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	grafanaHTTPClientCache.items[in.Name] = &cachedHTTPClient{resourceVersion: rv, client: cli}
+	return cli, nil
+}
+
+func (in *AccessCredential) hasTLSOptions() bool {
+	return len(in.CAData) > 0 || len(in.CertData) > 0 || in.InsecureSkipVerify
+}
+
+func (in *Grafana) newHTTPClient() (*http.Client, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// skipping verification is only enabled if explicitly requested
+		InsecureSkipVerify: in.Spec.Access.InsecureSkipVerify,
+	}
+	if len(in.Spec.Access.CAData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(in.Spec.Access.CAData) {
+			return nil, fmt.Errorf("no valid certificate found in the CA bundle of grafana %s", in.Name)
+		}
+		cfg.RootCAs = pool
+	}
+	if len(in.Spec.Access.CertData) > 0 {
+		cert, err := tls.X509KeyPair(in.Spec.Access.CertData, in.Spec.Access.KeyData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate of grafana %s: %w", in.Name, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{Transport: transport}, nil
+}
@@ -18,7 +18,10 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,13 +39,20 @@ type Grafana struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GrafanaSpec `json:"spec,omitempty"`
+	Spec   GrafanaSpec   `json:"spec,omitempty"`
+	Status GrafanaStatus `json:"status,omitempty"`
 }
 
 // GrafanaSpec defines the spec for grafana instance
 type GrafanaSpec struct {
 	Endpoint string           `json:"endpoint"`
 	Access   AccessCredential `json:"access"`
+	// OrgID is the default org for requests to grafana, the current org of
+	// the credential is used if not set
+	OrgID int64 `json:"orgID,omitempty"`
+	// Bootstrap exchanges the basic auth credential for a service account
+	// token on creation
+	Bootstrap *GrafanaBootstrap `json:"bootstrap,omitempty"`
 }
 
 // BasicAuth defines the basic auth credential
@@ -55,6 +65,25 @@ type BasicAuth struct {
 type AccessCredential struct {
 	*BasicAuth `json:",inline,omitempty"`
 	Token      *string `json:"token,omitempty"`
+	// SecretRef references the token or basic auth credential in an existing
+	// secret. If set, the inline token and basic auth are not stored.
+	SecretRef *GrafanaSecretRef `json:"secretRef,omitempty"`
+
+	// CAData is the PEM-encoded CA bundle for verifying the grafana endpoint
+	CAData []byte `json:"caData,omitempty"`
+	// CertData is the PEM-encoded client certificate for mutual TLS
+	CertData []byte `json:"certData,omitempty"`
+	// KeyData is the PEM-encoded client key for mutual TLS
+	KeyData []byte `json:"keyData,omitempty"`
+	// InsecureSkipVerify skips the verification of the grafana endpoint
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CredentialType is the type of the stored credential, only returned
+	// when the credential is redacted
+	CredentialType GrafanaCredentialType `json:"credentialType,omitempty"`
+	// Fingerprint is the fingerprint of the stored credential, only returned
+	// when the credential is redacted
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
 // GrafanaList list for Grafana
@@ -72,6 +101,7 @@ var _ rest.Lister = &Grafana{}
 var _ rest.CreaterUpdater = &Grafana{}
 var _ rest.Patcher = &Grafana{}
 var _ rest.GracefulDeleter = &Grafana{}
+var _ resource.ObjectWithArbitrarySubResource = &Grafana{}
 
 // GetObjectMeta returns the object meta reference.
 func (in *Grafana) GetObjectMeta() *metav1.ObjectMeta {
@@ -114,45 +144,146 @@ func (in *Grafana) ShortNames() []string {
 const (
 	grafanaSecretNamePrefix            = "grafana."
 	grafanaSecretEndpointAnnotationKey = "o11y.oam.dev/grafana-endpoint"
+	grafanaSecretOrgIDAnnotationKey    = "o11y.oam.dev/grafana-default-org-id"
 	grafanaSecretUsernameKey           = "username"
 	grafanaSecretPasswordKey           = "password"
 	grafanaSecretTokenKey              = "token"
+	grafanaSecretCAKey                 = "ca.crt"
+	grafanaSecretCertKey               = "tls.crt"
+	grafanaSecretKeyKey                = "tls.key"
+	grafanaSecretInsecureKey           = "insecureSkipVerify"
+	grafanaSecretRefKey                = "secretRef"
+	grafanaSecretBootstrapKey          = "bootstrap"
 )
 
-// Get finds a resource in the storage by name and returns it.
+// Get finds a resource in the storage by name and returns it. The status is
+// filled with the cached one, and is probed through the status subresource.
 func (in *Grafana) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
-	return NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, name)
+	grafana, err := NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	grafana.loadCachedStatus()
+	if err = grafana.Redact(ctx); err != nil {
+		return nil, err
+	}
+	return grafana, nil
 }
 
 // List selects resources in the storage which match to the selector. 'options' can be nil.
+// The status of each grafana is filled with the cached one.
 func (in *Grafana) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
-	return NewGrafanaClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+	grafanas, err := NewGrafanaClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+	if err != nil {
+		return nil, err
+	}
+	for i := range grafanas.Items {
+		grafanas.Items[i].loadCachedStatus()
+		if err = grafanas.Items[i].Redact(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return grafanas, nil
 }
 
-// Create creates a new version of a resource.
+// Create creates a new version of a resource. For dry-run requests, the
+// connectivity of grafana is checked instead of creating it. If bootstrap is
+// set, the basic auth credential is exchanged for a service account token.
 func (in *Grafana) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
-	return obj, NewGrafanaClient(singleton.KubeClient.Get()).Create(ctx, obj.(*Grafana))
+	grafana := obj.(*Grafana).DeepCopy()
+	if !grafana.Spec.Access.hasCredential() {
+		return nil, apierrors.NewBadRequest(NewEmptyCredentialGrafanaError().Error())
+	}
+	if err := grafana.Spec.Access.SecretRef.validate(ctx); err != nil {
+		return nil, err
+	}
+	// load the fingerprint key ahead so that the created grafana can be redacted
+	if _, err := loadFingerprintKey(ctx, singleton.KubeClient.Get()); err != nil {
+		return nil, err
+	}
+	if options != nil && len(options.DryRun) > 0 {
+		if err := grafana.resolveSecretRef(ctx, singleton.KubeClient.Get()); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		if health := grafana.CheckHealth(ctx); !health.Healthy() {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("grafana is not healthy: %s", health.GetMessage()))
+		}
+	} else {
+		if err := grafana.Bootstrap(ctx); err != nil {
+			return nil, err
+		}
+		if err := NewGrafanaClient(singleton.KubeClient.Get()).Create(ctx, grafana); err != nil {
+			if grafana.Spec.Bootstrap != nil {
+				_ = grafana.RevokeToken(ctx, grafana.Spec.Bootstrap.TokenID)
+			}
+			return nil, err
+		}
+	}
+	if err := grafana.Redact(ctx); err != nil {
+		return nil, err
+	}
+	return grafana, nil
 }
 
-// Update finds a resource in the storage and updates it.
-func (in *Grafana) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (obj runtime.Object, _ bool, err error) {
+// Update finds a resource in the storage and updates it. The updated object
+// is built upon the redacted one, and the stored credential is kept if the
+// credential is left redacted. The stored credential is never sent to a new
+// endpoint, so the credential must be supplied again along with the endpoint.
+func (in *Grafana) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
 	cli := NewGrafanaClient(singleton.KubeClient.Get())
-	if obj, err = cli.Get(ctx, name); err != nil {
+	existing, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	redacted := existing.DeepCopy()
+	if err = redacted.Redact(ctx); err != nil {
 		return nil, false, err
 	}
-	if obj, err = objInfo.UpdatedObject(ctx, obj); err != nil {
+	obj, err := objInfo.UpdatedObject(ctx, redacted)
+	if err != nil {
 		return nil, false, err
 	}
-	return obj, false, cli.Update(ctx, obj.(*Grafana))
+	grafana := obj.(*Grafana).DeepCopy()
+	if restored := grafana.Spec.Access.restoreRedacted(&existing.Spec.Access); restored && grafana.Spec.Endpoint != existing.Spec.Endpoint {
+		return nil, false, apierrors.NewBadRequest("the credential must be supplied again when the endpoint is changed")
+	}
+	if !grafana.Spec.Access.hasCredential() {
+		return nil, false, apierrors.NewBadRequest(NewEmptyCredentialGrafanaError().Error())
+	}
+	if ref := grafana.Spec.Access.SecretRef; ref != nil && (grafana.Spec.Endpoint != existing.Spec.Endpoint || !reflect.DeepEqual(ref, existing.Spec.Access.SecretRef)) {
+		if err = ref.validate(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+	deleteCachedGrafanaStatus(name)
+	if err = cli.Update(ctx, grafana); err != nil {
+		return nil, false, err
+	}
+	if err = grafana.Redact(ctx); err != nil {
+		return nil, false, err
+	}
+	return grafana, false, nil
 }
 
 // Delete finds a resource in the storage and deletes it.
-func (in *Grafana) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (obj runtime.Object, _ bool, err error) {
+func (in *Grafana) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
 	cli := NewGrafanaClient(singleton.KubeClient.Get())
-	if obj, err = cli.Get(ctx, name); err != nil {
+	grafana, err := cli.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	redacted := grafana.DeepCopy()
+	if err = redacted.Redact(ctx); err != nil {
 		return nil, false, err
 	}
-	return obj, true, cli.Delete(ctx, obj.(*Grafana))
+	deleteCachedGrafanaStatus(name)
+	if err = cli.Delete(ctx, grafana); err != nil {
+		return nil, false, err
+	}
+	return redacted, true, nil
 }
 
-// TODO add access check subresource
+// GetArbitrarySubResources returns the list of arbitrary subresources for Grafana
+func (in *Grafana) GetArbitrarySubResources() []resource.ArbitrarySubResource {
+	return []resource.ArbitrarySubResource{&GrafanaHealthCheck{}, &GrafanaTokenRotation{}}
+}
@@ -18,6 +18,11 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 
 	"github.com/kubevela/pkg/util/k8s"
 	. "github.com/onsi/ginkgo/v2"
@@ -28,8 +33,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
 
 	"github.com/kubevela/pkg/util/singleton"
 
@@ -94,17 +104,26 @@ var _ = Describe("Test Grafana API", func() {
 		}
 
 		By("Test Get Grafana")
+		key, err := loadFingerprintKey(ctx, singleton.KubeClient.Get())
+		Ω(err).To(Succeed())
+		keySecret := &corev1.Secret{}
+		Ω(singleton.KubeClient.Get().Get(ctx, types.NamespacedName{Namespace: config.ObservabilityNamespace, Name: grafanaFingerprintKeySecretName}, keySecret)).To(Succeed())
+		Ω(keySecret.Data[grafanaFingerprintKeyKey]).To(Equal(key))
 		obj, err := s.Get(ctx, "example1", nil)
 		Ω(err).To(Succeed())
 		grafana, ok := obj.(*Grafana)
 		Ω(ok).To(BeTrue())
-		Ω(grafana.Spec).To(Equal(g1.Spec))
+		Ω(grafana.Spec.Endpoint).To(Equal(g1.Spec.Endpoint))
+		Ω(grafana.Spec.Access.Token).To(BeNil())
+		Ω(grafana.Spec.Access.Fingerprint).To(Equal(g1.Spec.Access.getFingerprint(key)))
 		Ω(grafana.GetCredentialType()).To(Equal(GrafanaCredentialTypeBearerToken))
 		obj, err = s.Get(ctx, "example2", nil)
 		Ω(err).To(Succeed())
 		grafana, ok = obj.(*Grafana)
 		Ω(ok).To(BeTrue())
-		Ω(grafana.Spec).To(Equal(g2.Spec))
+		Ω(grafana.Spec.Endpoint).To(Equal(g2.Spec.Endpoint))
+		Ω(grafana.Spec.Access.BasicAuth).To(BeNil())
+		Ω(grafana.Spec.Access.Fingerprint).To(Equal(g2.Spec.Access.getFingerprint(key)))
 		Ω(grafana.GetCredentialType()).To(Equal(GrafanaCredentialTypeBasicAuth))
 		_, err = s.Get(ctx, "example4", nil)
 		Ω(err).To(Satisfy(errors.IsNotFound))
@@ -132,16 +151,33 @@ var _ = Describe("Test Grafana API", func() {
 		Ω(err).To(Succeed())
 
 		By("Test Update Grafana")
-		obj, _, err = s.Update(ctx, "example3", rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (transformedNewObj runtime.Object, err error) {
-			obj := oldObj.(*Grafana).DeepCopy()
-			obj.Spec.Endpoint = "test"
-			obj.SetLabels(map[string]string{"key": "value"})
-			return obj, nil
-		}), nil, nil, false, nil)
+		update := func(fn func(*Grafana)) (runtime.Object, error) {
+			obj, _, err := s.Update(ctx, "example3", rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (transformedNewObj runtime.Object, err error) {
+				obj := oldObj.(*Grafana).DeepCopy()
+				fn(obj)
+				return obj, nil
+			}), nil, nil, false, nil)
+			return obj, err
+		}
+		obj, err = update(func(obj *Grafana) { obj.SetLabels(map[string]string{"key": "value"}) })
 		Ω(err).To(Succeed())
 		grafana, ok = obj.(*Grafana)
 		Ω(ok).To(BeTrue())
-		Ω(grafana.Spec.Endpoint).To(Equal("test"))
+		Ω(grafana.Spec.Access.BasicAuth).To(BeNil())
+		stored, err := NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, "example3")
+		Ω(err).To(Succeed())
+		Ω(stored.Spec.Access.BasicAuth).To(Equal(g3.Spec.Access.BasicAuth))
+		_, err = update(func(obj *Grafana) { obj.Spec.Endpoint = "test" })
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+		obj, err = update(func(obj *Grafana) {
+			obj.Spec.Endpoint = "test"
+			obj.Spec.Access.BasicAuth = &BasicAuth{Username: "admin", Password: "admin"}
+		})
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).Spec.Endpoint).To(Equal("test"))
+		stored, err = NewGrafanaClient(singleton.KubeClient.Get()).Get(ctx, "example3")
+		Ω(err).To(Succeed())
+		Ω(stored.Spec.Access.BasicAuth).To(Equal(&BasicAuth{Username: "admin", Password: "admin"}))
 		objs, err = s.List(ctx, &metainternalversion.ListOptions{LabelSelector: labels.SelectorFromSet(map[string]string{"key": "value"})})
 		Ω(err).To(Succeed())
 		grafanas, ok = objs.(*GrafanaList)
@@ -161,4 +197,298 @@ var _ = Describe("Test Grafana API", func() {
 		Ω(len(grafanas.Items)).To(Equal(2))
 	})
 
+	It("Test Grafana TLS", func() {
+		ctx := context.Background()
+		svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"database":"ok","version":"9.3.0","id":1,"name":"Main Org."}`))
+		}))
+		defer svr.Close()
+		grafana := &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+			Spec:       GrafanaSpec{Endpoint: svr.URL, Access: AccessCredential{Token: pointer.String("-")}},
+		}
+		_, err := (&Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+		obj, err := (&GrafanaProbe{}).Get(ctx, "tls", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).GetReadyStatus()).To(Equal("False"))
+
+		By("Test with CA bundle")
+		caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+		obj, _, err = (&Grafana{}).Update(ctx, "tls", rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.(*Grafana).DeepCopy()
+			obj.Spec.Access.CAData = caData
+			return obj, nil
+		}), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		obj, err = (&GrafanaProbe{}).Get(ctx, "tls", nil)
+		Ω(err).To(Succeed())
+		grafana = obj.(*Grafana)
+		Ω(grafana.Spec.Access.CAData).To(Equal(caData))
+		Ω(grafana.GetReadyStatus()).To(Equal("True"))
+
+		By("Test with insecure skip verify")
+		grafana.SetResourceVersion("")
+		grafana.Spec.Access.CAData = nil
+		grafana.Spec.Access.InsecureSkipVerify = true
+		Ω(grafana.CheckHealth(ctx).Healthy()).To(BeTrue())
+		grafana.Spec.Access.CAData = []byte("-")
+		_, err = grafana.GetHTTPClient()
+		Ω(err).ShouldNot(Succeed())
+	})
+
+	It("Test Grafana Health", func() {
+		ctx := context.Background()
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/health" {
+				_, _ = w.Write([]byte(`{"commit":"abc","database":"ok","version":"9.3.0"}`))
+				return
+			}
+			if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "admin" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/api/user":
+				_, _ = w.Write([]byte(`{"login":"admin","orgId":1}`))
+			case "/api/org":
+				_, _ = w.Write([]byte(`{"id":1,"name":"Main Org."}`))
+			case "/api/user/orgs":
+				_, _ = w.Write([]byte(`[{"orgId":1,"name":"Main Org.","role":"Admin"}]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer svr.Close()
+		grafana := &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "health"},
+			Spec:       GrafanaSpec{Endpoint: svr.URL, Access: AccessCredential{BasicAuth: &BasicAuth{Username: "admin", Password: "admin"}}},
+		}
+
+		By("Test dry-run create with invalid credential")
+		invalid := grafana.DeepCopy()
+		invalid.Spec.Access.Password = "-"
+		_, err := (&Grafana{}).Create(ctx, invalid, nil, &metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+		_, err = (&Grafana{}).Get(ctx, "health", nil)
+		Ω(err).To(Satisfy(errors.IsNotFound))
+
+		By("Test dry-run create with valid credential")
+		_, err = (&Grafana{}).Create(ctx, grafana, nil, &metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		Ω(err).To(Succeed())
+		_, err = (&Grafana{}).Get(ctx, "health", nil)
+		Ω(err).To(Satisfy(errors.IsNotFound))
+
+		By("Test health subresource")
+		_, err = (&Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+		s := &GrafanaHealthCheck{}
+		Ω(s.SubResourceName()).To(Equal(GrafanaHealthSubResource))
+		obj, err := s.Get(ctx, "health", nil)
+		Ω(err).To(Succeed())
+		health := obj.(*GrafanaHealth)
+		Ω(health.Healthy()).To(BeTrue())
+		Ω(health.Version).To(Equal("9.3.0"))
+		Ω(health.User).To(Equal("admin"))
+		Ω(health.OrgName).To(Equal("Main Org."))
+		Ω(health.Role).To(Equal("Admin"))
+		Ω(invalid.CheckHealth(ctx).Healthy()).To(BeFalse())
+
+		By("Test status")
+		obj, err = (&Grafana{}).Get(ctx, "health", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).Status.LastProbeTime).To(BeNil())
+		p := &GrafanaProbe{}
+		obj, err = p.Get(ctx, "health", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).GetReadyStatus()).To(Equal("True"))
+		obj, err = (&Grafana{}).Get(ctx, "health", nil)
+		Ω(err).To(Succeed())
+		grafana = obj.(*Grafana)
+		Ω(grafana.GetReadyStatus()).To(Equal("True"))
+		Ω(grafana.Status.Version).To(Equal("9.3.0"))
+		Ω(grafana.Status.OrgName).To(Equal("Main Org."))
+		Ω(grafana.Status.LastContactTime).ShouldNot(BeNil())
+		objs, err := (&Grafana{}).List(ctx, nil)
+		Ω(err).To(Succeed())
+		for _, item := range objs.(*GrafanaList).Items {
+			if item.Name == "health" {
+				Ω(item.GetReadyStatus()).To(Equal("True"))
+			}
+		}
+		table, err := (&Grafana{}).ConvertToTable(ctx, grafana, nil)
+		Ω(err).To(Succeed())
+		Ω(table.Rows[0].Cells[3:6]).To(Equal([]interface{}{"True", "9.3.0", "Main Org."}))
+
+		By("Test status probed after the cached one expires")
+		ttl := GrafanaStatusCacheTTL
+		GrafanaStatusCacheTTL = 0
+		defer func() { GrafanaStatusCacheTTL = ttl }()
+		svr.Close()
+		obj, err = p.Get(ctx, "health", nil)
+		Ω(err).To(Succeed())
+		grafana = obj.(*Grafana)
+		Ω(grafana.GetReadyStatus()).To(Equal("False"))
+		Ω(grafana.Status.LastContactTime).ShouldNot(BeNil())
+	})
+
+	It("Test Grafana SecretRef", func() {
+		loopback.SetAuthorizer(authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			if a.GetUser().GetName() == "admin" {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionDeny, "", nil
+		}))
+		ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin"})
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: config.ObservabilityNamespace},
+			Data:       map[string][]byte{"api-key": []byte("external-token")},
+		}
+		Ω(singleton.KubeClient.Get().Create(ctx, secret)).To(Succeed())
+		ref := &GrafanaSecretRef{Namespace: config.ObservabilityNamespace, Name: "external", Keys: GrafanaSecretRefKeys{Token: "api-key"}}
+		grafana := &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "ref"},
+			Spec:       GrafanaSpec{Endpoint: "-", Access: AccessCredential{SecretRef: ref}},
+		}
+		_, err := (&Grafana{}).Create(context.Background(), grafana, nil, nil)
+		Ω(err).To(Satisfy(errors.IsForbidden))
+		_, err = (&Grafana{}).Create(request.WithUser(context.Background(), &user.DefaultInfo{Name: "dev"}), grafana, nil, nil)
+		Ω(err).To(Satisfy(errors.IsForbidden))
+		_, err = (&Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+		_, err = (&Grafana{}).Create(ctx, &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "bad-ref"},
+			Spec:       GrafanaSpec{Endpoint: "-", Access: AccessCredential{SecretRef: &GrafanaSecretRef{Name: "external"}}},
+		}, nil, nil)
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+
+		By("Test credential resolved at request time")
+		cli := NewGrafanaClient(singleton.KubeClient.Get())
+		grafana, err = cli.Get(ctx, "ref")
+		Ω(err).To(Succeed())
+		Ω(grafana.Spec.Access.SecretRef).To(Equal(ref))
+		Ω(*grafana.Spec.Access.Token).To(Equal("external-token"))
+		Ω(grafana.ToSecret().Data).ShouldNot(HaveKey(grafanaSecretTokenKey))
+		secret.Data = map[string][]byte{"username": []byte("admin"), "password": []byte("admin")}
+		Ω(singleton.KubeClient.Get().Update(ctx, secret)).To(Succeed())
+		grafana, err = cli.Get(ctx, "ref")
+		Ω(err).To(Succeed())
+		Ω(grafana.GetCredentialType()).To(Equal(GrafanaCredentialTypeBasicAuth))
+		obj, err := (&Grafana{}).Get(ctx, "ref", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).Spec.Access.BasicAuth).To(BeNil())
+		Ω(obj.(*Grafana).Spec.Access.SecretRef).To(Equal(ref))
+
+		By("Test secretRef validated again when the endpoint changes")
+		changeEndpoint := rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.(*Grafana).DeepCopy()
+			obj.Spec.Endpoint = "changed"
+			return obj, nil
+		})
+		_, _, err = (&Grafana{}).Update(request.WithUser(context.Background(), &user.DefaultInfo{Name: "dev"}), "ref", changeEndpoint, nil, nil, false, nil)
+		Ω(err).To(Satisfy(errors.IsForbidden))
+		obj, _, err = (&Grafana{}).Update(ctx, "ref", changeEndpoint, nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).Spec.Endpoint).To(Equal("changed"))
+
+		By("Test switch from secretRef to inline credential")
+		_, err = (&Grafana{}).Create(ctx, &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "ref-inline"},
+			Spec:       GrafanaSpec{Endpoint: "-", Access: AccessCredential{SecretRef: ref}},
+		}, nil, nil)
+		Ω(err).To(Succeed())
+		toInline := func(token *string) rest.UpdatedObjectInfo {
+			return rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (runtime.Object, error) {
+				obj := oldObj.(*Grafana).DeepCopy()
+				obj.Spec.Access.SecretRef = nil
+				obj.Spec.Access.Token = token
+				return obj, nil
+			})
+		}
+		_, _, err = (&Grafana{}).Update(ctx, "ref-inline", toInline(nil), nil, nil, false, nil)
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+		_, _, err = (&Grafana{}).Update(ctx, "ref-inline", toInline(pointer.String("inline-token")), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		grafana, err = cli.Get(ctx, "ref-inline")
+		Ω(err).To(Succeed())
+		Ω(grafana.Spec.Access.SecretRef).To(BeNil())
+		Ω(*grafana.Spec.Access.Token).To(Equal("inline-token"))
+
+		By("Test referenced secret removed")
+		Ω(singleton.KubeClient.Get().Delete(ctx, secret)).To(Succeed())
+		_, err = cli.Get(ctx, "ref")
+		Ω(err).ShouldNot(Succeed())
+		grafanas, err := cli.List(ctx)
+		Ω(err).To(Succeed())
+		Ω(len(grafanas.Items)).To(Equal(2))
+	})
+
+	It("Test Grafana Bootstrap", func() {
+		ctx := context.Background()
+		var tokens []int64
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, _, basic := r.BasicAuth()
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			switch {
+			case r.URL.Path == "/api/serviceaccounts/search" && basic && username == "admin":
+				_, _ = w.Write([]byte(`{"serviceAccounts":[{"id":1,"name":"other"}]}`))
+			case r.URL.Path == "/api/serviceaccounts" && r.Method == http.MethodPost && basic:
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":2,"name":"prism-bootstrap","role":"Admin"}`))
+			case r.URL.Path == "/api/serviceaccounts/2/tokens" && r.Method == http.MethodPost:
+				id := int64(len(tokens) + 1)
+				tokens = append(tokens, id)
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"id":%d,"name":"prism","key":"glsa_%d"}`, id, id)))
+			case strings.HasPrefix(r.URL.Path, "/api/serviceaccounts/2/tokens/") && r.Method == http.MethodDelete && token != "":
+				tokens = tokens[1:]
+				_, _ = w.Write([]byte(`{}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer svr.Close()
+		grafana := &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "bootstrap"},
+			Spec: GrafanaSpec{
+				Endpoint:  svr.URL,
+				Access:    AccessCredential{BasicAuth: &BasicAuth{Username: "admin", Password: "admin"}},
+				Bootstrap: &GrafanaBootstrap{},
+			},
+		}
+		obj, err := (&Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*Grafana).GetCredentialType()).To(Equal(GrafanaCredentialTypeBearerToken))
+		cli := NewGrafanaClient(singleton.KubeClient.Get())
+		grafana, err = cli.Get(ctx, "bootstrap")
+		Ω(err).To(Succeed())
+		Ω(grafana.Spec.Access.BasicAuth).To(BeNil())
+		Ω(*grafana.Spec.Access.Token).To(Equal("glsa_1"))
+		Ω(*grafana.Spec.Bootstrap).To(Equal(GrafanaBootstrap{ServiceAccount: "prism-bootstrap", Role: "Admin", ServiceAccountID: 2, TokenID: 1}))
+
+		By("Test rotate token")
+		r := &GrafanaTokenRotation{}
+		Ω(r.SubResourceName()).To(Equal(GrafanaRotateTokenSubResource))
+		_, _, err = r.Update(ctx, "bootstrap", rest.DefaultUpdatedObjectInfo(&Grafana{}), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		grafana, err = cli.Get(ctx, "bootstrap")
+		Ω(err).To(Succeed())
+		Ω(*grafana.Spec.Access.Token).To(Equal("glsa_2"))
+		Ω(grafana.Spec.Bootstrap.TokenID).To(Equal(int64(2)))
+		Ω(tokens).To(Equal([]int64{2}))
+
+		By("Test bootstrap without basic auth")
+		_, err = (&Grafana{}).Create(ctx, &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-basic-auth"},
+			Spec:       GrafanaSpec{Endpoint: svr.URL, Access: AccessCredential{Token: pointer.String("-")}, Bootstrap: &GrafanaBootstrap{}},
+		}, nil, nil)
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+		_, err = (&Grafana{}).Create(ctx, &Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-bootstrap"},
+			Spec:       GrafanaSpec{Endpoint: svr.URL, Access: AccessCredential{Token: pointer.String("-")}},
+		}, nil, nil)
+		Ω(err).To(Succeed())
+		_, _, err = r.Update(ctx, "no-bootstrap", rest.DefaultUpdatedObjectInfo(&Grafana{}), nil, nil, false, nil)
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+	})
+
 })
@@ -22,12 +22,14 @@ import (
 	"net/http"
 	"net/url"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubevela/pkg/util/apiserver"
 
 	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
+	grafanafolderv1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafanafolder/v1alpha1"
 	"github.com/kubevela/prism/pkg/util/subresource"
 )
 
@@ -43,11 +45,15 @@ type GrafanaDashboardClient interface {
 
 // NewGrafanaDashboardClient create GrafanaDashboardClient
 func NewGrafanaDashboardClient(cli client.Client) GrafanaDashboardClient {
-	return &grafanaDashboardClient{grafanav1alpha1.NewGrafanaClient(cli)}
+	return &grafanaDashboardClient{
+		GrafanaClient: grafanav1alpha1.NewGrafanaClient(cli),
+		folderClient:  grafanafolderv1alpha1.NewGrafanaFolderClient(cli),
+	}
 }
 
 type grafanaDashboardClient struct {
 	grafanav1alpha1.GrafanaClient
+	folderClient grafanafolderv1alpha1.GrafanaFolderClient
 }
 
 func (in *grafanaDashboardClient) Get(ctx context.Context, name string) (*GrafanaDashboard, error) {
@@ -65,6 +71,9 @@ func (in *grafanaDashboardClient) Get(ctx context.Context, name string) (*Grafan
 }
 
 func (in *grafanaDashboardClient) Create(ctx context.Context, dashboard *GrafanaDashboard) error {
+	if err := in.resolveFolder(ctx, dashboard); err != nil {
+		return err
+	}
 	return grafanav1alpha1.NewGrafanaSubResourceRequest(dashboard, dashboard.GetName()).
 		WithMethod(http.MethodPost).
 		WithPathFunc(func() (string, error) {
@@ -74,6 +83,24 @@ func (in *grafanaDashboardClient) Create(ctx context.Context, dashboard *Grafana
 		Do(ctx, in.GrafanaClient)
 }
 
+// resolveFolder resolves the folder title into the folder uid of the dashboard
+func (in *grafanaDashboardClient) resolveFolder(ctx context.Context, dashboard *GrafanaDashboard) error {
+	title := dashboard.GetAnnotations()[GrafanaDashboardFolderTitleAnnotationKey]
+	if title == "" {
+		return nil
+	}
+	grafanaName, orgID, err := grafanav1alpha1.ResolveOrgID(subresource.NewCompoundName(dashboard.GetName()), dashboard)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	folder, err := in.folderClient.Ensure(ctx, grafanaName, orgID, title)
+	if err != nil {
+		return err
+	}
+	dashboard.setFolderUID(subresource.NewCompoundName(folder.GetName()).SubResourceName)
+	return nil
+}
+
 func (in *grafanaDashboardClient) Update(ctx context.Context, dashboard *GrafanaDashboard) error {
 	return in.Create(ctx, dashboard)
 }
@@ -91,6 +118,8 @@ func (in *grafanaDashboardClient) Delete(ctx context.Context, dashboard *Grafana
 func (in *grafanaDashboardClient) List(ctx context.Context, options ...client.ListOption) (*GrafanaDashboardList, error) {
 	opts := apiserver.NewListOptions(options...)
 	parentResourceName := subresource.GetParentResourceNameFromLabelSelector(opts.LabelSelector, "grafana")
+	orgID := grafanav1alpha1.GetOrgIDFromLabelSelector(opts.LabelSelector)
+	parentResourceName = grafanav1alpha1.NewOrgParentResourceName(parentResourceName, orgID)
 	params := apiserver.BuildQueryParamsFromLabelSelector(opts.LabelSelector, "query", "tag", "folderIds", "dashboardIds", "starred")
 	dashboards := &GrafanaDashboardList{}
 	return dashboards, grafanav1alpha1.NewGrafanaSubResourceRequest(&grafanav1alpha1.Grafana{}, (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()).
@@ -98,8 +127,15 @@ func (in *grafanaDashboardClient) List(ctx context.Context, options ...client.Li
 		WithPathFunc(func() (string, error) {
 			return fmt.Sprintf("/api/search?type=dash-db%s", params), nil
 		}).
+		WithOrgID(orgID).
 		WithOnSuccess(func(respBody []byte) error {
-			return dashboards.FromResponseBody(respBody, parentResourceName)
+			if err := dashboards.FromResponseBody(respBody, parentResourceName); err != nil {
+				return err
+			}
+			for i := range dashboards.Items {
+				grafanav1alpha1.SetOrgID(&dashboards.Items[i], orgID)
+			}
+			return nil
 		}).
 		Do(ctx, in.GrafanaClient)
 }
@@ -29,8 +29,23 @@ import (
 const (
 	grafanaDashboardFolderIdLabelKey  = "o11y.oam.dev/grafana-dashboard-folder-id"
 	grafanaDashboardFolderUidLabelKey = "o11y.oam.dev/grafana-dashboard-folder-uid"
+	// GrafanaDashboardFolderTitleAnnotationKey the annotation key for the
+	// folder title of the dashboard, which overrides the folder id and uid
+	// labels. The folder is created if not exists.
+	GrafanaDashboardFolderTitleAnnotationKey = "o11y.oam.dev/grafana-dashboard-folder-title"
 )
 
+// setFolderUID places the dashboard into the folder
+func (in *GrafanaDashboard) setFolderUID(uid string) {
+	labels := in.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	delete(labels, grafanaDashboardFolderIdLabelKey)
+	labels[grafanaDashboardFolderUidLabelKey] = uid
+	in.SetLabels(labels)
+}
+
 // ToRequestBody convert object into body for request
 func (in *GrafanaDashboard) ToRequestBody() ([]byte, error) {
 	dashboard := map[string]interface{}{}
@@ -30,7 +30,10 @@ import (
 	"github.com/kubevela/pkg/util/singleton"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/utils/pointer"
@@ -50,10 +53,14 @@ var _ = Describe("Test GrafanaDashboard API", func() {
 
 	var mockServer *httptest.Server
 	var data map[string][]byte
+	var folders map[string]string
+	var dashboardFolders map[string]string
 
 	BeforeEach(func() {
 		Ω(k8s.EnsureNamespace(context.Background(), singleton.KubeClient.Get(), config.ObservabilityNamespace)).To(Succeed())
 		data = map[string][]byte{}
+		folders = map[string]string{"existing": "Existing"}
+		dashboardFolders = map[string]string{}
 		mockServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			p := request.Method + " " + request.URL.Path
 			switch {
@@ -65,7 +72,23 @@ var _ = Describe("Test GrafanaDashboard API", func() {
 				uid := dashboard["uid"].(string)
 				bs, _ = json.Marshal(dashboard)
 				data[uid] = bs
+				if folderUID, ok := m["folderUid"].(string); ok {
+					dashboardFolders[uid] = folderUID
+				}
 				writer.WriteHeader(http.StatusOK)
+			case p == "GET /api/search" && request.URL.Query().Get("type") == "dash-folder":
+				var items []string
+				for uid, title := range folders {
+					items = append(items, fmt.Sprintf(`{"uid":%q,"title":%q,"type":"dash-folder"}`, uid, title))
+				}
+				_, _ = writer.Write([]byte("[" + strings.Join(items, ",") + "]"))
+			case p == "POST /api/folders":
+				bs, _ := io.ReadAll(request.Body)
+				var m map[string]interface{}
+				_ = json.Unmarshal(bs, &m)
+				uid := fmt.Sprintf("generated-%d", len(folders))
+				folders[uid] = m["title"].(string)
+				_, _ = writer.Write([]byte(fmt.Sprintf(`{"id":%d,"uid":%q,"title":%q}`, len(folders), uid, folders[uid])))
 			case strings.HasPrefix(p, "GET /api/dashboards/uid/"):
 				uid := strings.TrimPrefix(p, "GET /api/dashboards/uid/")
 				db, ok := data[uid]
@@ -176,4 +199,133 @@ var _ = Describe("Test GrafanaDashboard API", func() {
 		Ω(err).To(Succeed())
 	})
 
+	It("Test GrafanaDashboard Folder Title", func() {
+		ctx := context.Background()
+		grafana := &grafanav1alpha1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: subresource.DefaultParentResourceName},
+			Spec: grafanav1alpha1.GrafanaSpec{
+				Endpoint: mockServer.URL,
+				Access:   grafanav1alpha1.AccessCredential{Token: pointer.String("mock")},
+			},
+		}
+		_, err := (&grafanav1alpha1.Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+
+		s := &GrafanaDashboard{}
+		By("Test existing folder")
+		obj, err := s.Create(ctx, &GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "alpha",
+				Labels:      map[string]string{grafanaDashboardFolderIdLabelKey: "1"},
+				Annotations: map[string]string{GrafanaDashboardFolderTitleAnnotationKey: "Existing"},
+			},
+			Spec: runtime.RawExtension{Raw: []byte(`{"key":"val"}`)},
+		}, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*GrafanaDashboard).GetLabels()).To(Equal(map[string]string{grafanaDashboardFolderUidLabelKey: "existing"}))
+		Ω(dashboardFolders["alpha"]).To(Equal("existing"))
+
+		By("Test folder created on demand")
+		_, err = s.Create(ctx, &GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "beta",
+				Annotations: map[string]string{GrafanaDashboardFolderTitleAnnotationKey: "New Folder"},
+			},
+			Spec: runtime.RawExtension{Raw: []byte(`{"key":"val"}`)},
+		}, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(folders).To(HaveKeyWithValue("generated-1", "New Folder"))
+		Ω(dashboardFolders["beta"]).To(Equal("generated-1"))
+	})
+
+	It("Test GrafanaDashboard in non-default org", func() {
+		ctx := context.Background()
+		// dashboards are stored by org, the org header is required
+		orgData := map[string]map[string][]byte{"1": {}, "2": {}}
+		svr := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			dbs, ok := orgData[request.Header.Get("X-Grafana-Org-Id")]
+			if !ok {
+				writer.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			p := request.Method + " " + request.URL.Path
+			uid := strings.TrimPrefix(request.URL.Path, "/api/dashboards/uid/")
+			switch {
+			case p == "POST /api/dashboards/db":
+				var m map[string]map[string]interface{}
+				bs, _ := io.ReadAll(request.Body)
+				_ = json.Unmarshal(bs, &m)
+				dbs[m["dashboard"]["uid"].(string)], _ = json.Marshal(m["dashboard"])
+			case strings.HasPrefix(p, "GET /api/dashboards/uid/") && dbs[uid] != nil:
+				_, _ = writer.Write([]byte(fmt.Sprintf(`{"dashboard":%s}`, dbs[uid])))
+			case strings.HasPrefix(p, "DELETE /api/dashboards/uid/") && dbs[uid] != nil:
+				delete(dbs, uid)
+			case p == "GET /api/search":
+				var items []string
+				for _, db := range dbs {
+					items = append(items, string(db))
+				}
+				_, _ = writer.Write([]byte("[" + strings.Join(items, ",") + "]"))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer svr.Close()
+		grafana := &grafanav1alpha1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: "org"},
+			Spec: grafanav1alpha1.GrafanaSpec{
+				Endpoint: svr.URL,
+				Access:   grafanav1alpha1.AccessCredential{Token: pointer.String("mock")},
+				OrgID:    1,
+			},
+		}
+		_, err := (&grafanav1alpha1.Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+
+		s := &GrafanaDashboard{}
+		By("Test create with org label")
+		_, err = s.Create(ctx, &GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: "alpha@org", Labels: map[string]string{grafanav1alpha1.GrafanaOrgIDLabelKey: "2"}},
+			Spec:       runtime.RawExtension{Raw: []byte(`{"key":"val"}`)},
+		}, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(orgData["2"]).To(HaveKey("alpha"))
+		_, err = s.Get(ctx, "alpha@org", nil)
+		Ω(err).To(Satisfy(errors.IsNotFound))
+
+		By("Test list returns names with org")
+		objs, err := s.List(ctx, &metainternalversion.ListOptions{LabelSelector: labels.SelectorFromSet(map[string]string{
+			"grafana": "org", grafanav1alpha1.GrafanaOrgIDLabelKey: "2",
+		})})
+		Ω(err).To(Succeed())
+		Ω(objs.(*GrafanaDashboardList).Items).To(HaveLen(1))
+		Ω(objs.(*GrafanaDashboardList).Items[0].GetName()).To(Equal("alpha@org@2"))
+
+		By("Test get with org in name")
+		obj, err := s.Get(ctx, "alpha@org@2", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*GrafanaDashboard).GetLabels()).To(HaveKeyWithValue(grafanav1alpha1.GrafanaOrgIDLabelKey, "2"))
+
+		By("Test update with org in name")
+		_, _, err = s.Update(ctx, "alpha@org@2", rest.DefaultUpdatedObjectInfo(nil, func(ctx context.Context, newObj runtime.Object, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.(*GrafanaDashboard).DeepCopy()
+			obj.Spec.Raw = []byte(`{"key":"v"}`)
+			return obj, nil
+		}), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+		Ω(orgData["1"]).To(BeEmpty())
+		Ω(string(orgData["2"]["alpha"])).To(ContainSubstring(`"key":"v"`))
+
+		By("Test org mismatch")
+		_, err = s.Create(ctx, &GrafanaDashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: "beta@org@2", Labels: map[string]string{grafanav1alpha1.GrafanaOrgIDLabelKey: "3"}},
+			Spec:       runtime.RawExtension{Raw: []byte(`{"key":"val"}`)},
+		}, nil, nil)
+		Ω(err).To(Satisfy(errors.IsBadRequest))
+
+		By("Test delete with org in name")
+		_, _, err = s.Delete(ctx, "alpha@org@2", nil, nil)
+		Ω(err).To(Succeed())
+		Ω(orgData["2"]).To(BeEmpty())
+	})
 })
@@ -103,14 +103,23 @@ func (in *grafanaDatasourceClient) Delete(ctx context.Context, datasource *Grafa
 func (in *grafanaDatasourceClient) List(ctx context.Context, options ...client.ListOption) (*GrafanaDatasourceList, error) {
 	opts := apiserver.NewListOptions(options...)
 	parentResourceName := subresource.GetParentResourceNameFromLabelSelector(opts.LabelSelector, "grafana")
+	orgID := grafanav1alpha1.GetOrgIDFromLabelSelector(opts.LabelSelector)
+	parentResourceName = grafanav1alpha1.NewOrgParentResourceName(parentResourceName, orgID)
 	datasources := &GrafanaDatasourceList{}
 	return datasources, grafanav1alpha1.NewGrafanaSubResourceRequest(&grafanav1alpha1.Grafana{}, (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()).
 		WithMethod(http.MethodGet).
 		WithPathFunc(func() (string, error) {
 			return "/api/datasources", nil
 		}).
+		WithOrgID(orgID).
 		WithOnSuccess(func(respBody []byte) error {
-			return datasources.FromResponseBody(respBody, parentResourceName)
+			if err := datasources.FromResponseBody(respBody, parentResourceName); err != nil {
+				return err
+			}
+			for i := range datasources.Items {
+				grafanav1alpha1.SetOrgID(&datasources.Items[i], orgID)
+			}
+			return nil
 		}).
 		Do(ctx, in.GrafanaClient)
 }
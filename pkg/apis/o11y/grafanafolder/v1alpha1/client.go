@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/apiserver"
+
+	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+// GrafanaFolderClient client for grafana folder
+// +kubebuilder:object:generate=false
+type GrafanaFolderClient interface {
+	Get(ctx context.Context, name string) (*GrafanaFolder, error)
+	List(ctx context.Context, options ...client.ListOption) (*GrafanaFolderList, error)
+	Create(ctx context.Context, grafanaFolder *GrafanaFolder) error
+	Update(ctx context.Context, grafanaFolder *GrafanaFolder) error
+	Delete(ctx context.Context, grafanaFolder *GrafanaFolder) error
+	// Ensure returns the folder with the given title in the grafana org, the
+	// folder is created if not exists. If the folder is created concurrently,
+	// the existing one is returned.
+	Ensure(ctx context.Context, grafanaName string, orgID int64, title string) (*GrafanaFolder, error)
+}
+
+// NewGrafanaFolderClient create GrafanaFolderClient
+func NewGrafanaFolderClient(cli client.Client) GrafanaFolderClient {
+	return &grafanaFolderClient{grafanav1alpha1.NewGrafanaClient(cli)}
+}
+
+type grafanaFolderClient struct {
+	grafanav1alpha1.GrafanaClient
+}
+
+func (in *grafanaFolderClient) Get(ctx context.Context, name string) (*GrafanaFolder, error) {
+	resourceName := subresource.NewCompoundName(name)
+	folder := &GrafanaFolder{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName.String(), UID: "-"},
+	}
+	return folder, grafanav1alpha1.NewGrafanaSubResourceRequest(folder, name).
+		WithMethod(http.MethodGet).
+		WithPathFunc(func() (string, error) {
+			return "/api/folders/" + url.PathEscape(resourceName.SubResourceName), nil
+		}).
+		WithOnSuccess(folder.FromResponseBody).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaFolderClient) Create(ctx context.Context, folder *GrafanaFolder) error {
+	return grafanav1alpha1.NewGrafanaSubResourceRequest(folder, folder.GetName()).
+		WithMethod(http.MethodPost).
+		WithPathFunc(func() (string, error) {
+			return "/api/folders", nil
+		}).
+		WithBodyFunc(folder.ToRequestBody).
+		WithOnSuccess(folder.FromResponseBody).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaFolderClient) Update(ctx context.Context, folder *GrafanaFolder) error {
+	resourceName := subresource.NewCompoundName(folder.GetName())
+	return grafanav1alpha1.NewGrafanaSubResourceRequest(folder, folder.GetName()).
+		WithMethod(http.MethodPut).
+		WithPathFunc(func() (string, error) {
+			return "/api/folders/" + url.PathEscape(resourceName.SubResourceName), nil
+		}).
+		WithBodyFunc(folder.ToRequestBody).
+		WithOnSuccess(folder.FromResponseBody).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaFolderClient) Delete(ctx context.Context, folder *GrafanaFolder) error {
+	resourceName := subresource.NewCompoundName(folder.GetName())
+	return grafanav1alpha1.NewGrafanaSubResourceRequest(folder, folder.GetName()).
+		WithMethod(http.MethodDelete).
+		WithPathFunc(func() (string, error) {
+			return "/api/folders/" + url.PathEscape(resourceName.SubResourceName), nil
+		}).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaFolderClient) List(ctx context.Context, options ...client.ListOption) (*GrafanaFolderList, error) {
+	opts := apiserver.NewListOptions(options...)
+	parentResourceName := subresource.GetParentResourceNameFromLabelSelector(opts.LabelSelector, "grafana")
+	orgID := grafanav1alpha1.GetOrgIDFromLabelSelector(opts.LabelSelector)
+	parentResourceName = grafanav1alpha1.NewOrgParentResourceName(parentResourceName, orgID)
+	folders := &GrafanaFolderList{}
+	return folders, grafanav1alpha1.NewGrafanaSubResourceRequest(&grafanav1alpha1.Grafana{}, (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()).
+		WithMethod(http.MethodGet).
+		WithPathFunc(func() (string, error) {
+			return "/api/folders", nil
+		}).
+		WithOrgID(orgID).
+		WithOnSuccess(func(respBody []byte) error {
+			if err := folders.FromResponseBody(respBody, parentResourceName); err != nil {
+				return err
+			}
+			for i := range folders.Items {
+				grafanav1alpha1.SetOrgID(&folders.Items[i], orgID)
+			}
+			return nil
+		}).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaFolderClient) Ensure(ctx context.Context, grafanaName string, orgID int64, title string) (*GrafanaFolder, error) {
+	parentResourceName := grafanav1alpha1.NewOrgParentResourceName(grafanaName, orgID)
+	folder, err := in.search(ctx, parentResourceName, orgID, title)
+	if err != nil || folder != nil {
+		return folder, err
+	}
+	folder = &GrafanaFolder{ObjectMeta: metav1.ObjectMeta{Name: (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()}}
+	if folder.Spec.Raw, err = json.Marshal(map[string]string{"title": title}); err != nil {
+		return nil, err
+	}
+	grafanav1alpha1.SetOrgID(folder, orgID)
+	err = in.Create(ctx, folder)
+	if !apierrors.IsConflict(err) {
+		return folder, err
+	}
+	// the folder could be created concurrently after the search
+	existing, searchErr := in.search(ctx, parentResourceName, orgID, title)
+	if searchErr != nil || existing == nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// search returns the folder with the given title in the grafana org, or nil if
+// not found
+func (in *grafanaFolderClient) search(ctx context.Context, parentResourceName string, orgID int64, title string) (*GrafanaFolder, error) {
+	folders := &GrafanaFolderList{}
+	err := grafanav1alpha1.NewGrafanaSubResourceRequest(&grafanav1alpha1.Grafana{}, (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()).
+		WithMethod(http.MethodGet).
+		WithPathFunc(func() (string, error) {
+			return "/api/search?type=dash-folder&query=" + url.QueryEscape(title), nil
+		}).
+		WithOrgID(orgID).
+		WithOnSuccess(func(respBody []byte) error {
+			return folders.FromResponseBody(respBody, parentResourceName)
+		}).
+		Do(ctx, in.GrafanaClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, folder := range folders.Items {
+		if apiserver.GetStringFromRawExtension(&folder.Spec, "title") == title {
+			return folder.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+// ToRequestBody convert object into body for request. The uid is left for
+// grafana to generate if not set in the name. The folder is overwritten on
+// update if no version is given.
+func (in *GrafanaFolder) ToRequestBody() ([]byte, error) {
+	folder := map[string]interface{}{}
+	if len(in.Spec.Raw) > 0 {
+		if err := json.Unmarshal(in.Spec.Raw, &folder); err != nil {
+			return nil, err
+		}
+	}
+	if uid := subresource.NewCompoundName(in.GetName()).SubResourceName; uid != "" {
+		folder["uid"] = uid
+	}
+	delete(folder, "id")
+	if _, found := folder["version"]; !found {
+		folder["overwrite"] = true
+	}
+	return json.Marshal(folder)
+}
+
+// FromResponseBody load folder from grafana api
+func (in *GrafanaFolder) FromResponseBody(respBody []byte) error {
+	folder := map[string]interface{}{}
+	if err := json.Unmarshal(respBody, &folder); err != nil {
+		return err
+	}
+	uid, ok := folder["uid"].(string)
+	if !ok {
+		return fmt.Errorf("invalid grafana folder response, no valid uid found")
+	}
+	name := subresource.NewCompoundName(in.GetName())
+	name.SubResourceName = uid
+	in.SetName(name.String())
+	in.Spec = runtime.RawExtension{Raw: respBody}
+	return nil
+}
+
+// FromResponseBody load folders from grafana api
+func (in *GrafanaFolderList) FromResponseBody(respBody []byte, parentResourceName string) error {
+	data := []map[string]interface{}{}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return err
+	}
+	in.Items = []GrafanaFolder{}
+	for _, raw := range data {
+		folder := &GrafanaFolder{}
+		uid, ok := raw["uid"].(string)
+		if !ok {
+			return fmt.Errorf("invalid grafana folder response, no valid uid found")
+		}
+		folder.SetName((&subresource.CompoundName{ParentResourceName: parentResourceName, SubResourceName: uid}).String())
+		bs, err := json.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		folder.Spec = runtime.RawExtension{Raw: bs}
+		in.Items = append(in.Items, *folder)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGrafanaFolderToRequestBody(t *testing.T) {
+	in := &GrafanaFolder{ObjectMeta: metav1.ObjectMeta{Name: "test@example"}}
+	in.Spec = runtime.RawExtension{Raw: []byte(`bad`)}
+	_, err := in.ToRequestBody()
+	require.NotNil(t, err)
+	in.Spec = runtime.RawExtension{Raw: []byte(`{"id":1,"title":"Test"}`)}
+	bs, err := in.ToRequestBody()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"overwrite":true,"title":"Test","uid":"test"}`), bs)
+	in.SetName("@example")
+	in.Spec = runtime.RawExtension{Raw: []byte(`{"title":"Test","version":2}`)}
+	bs, err = in.ToRequestBody()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"title":"Test","version":2}`), bs)
+}
+
+func TestGrafanaFolderFromResponseBody(t *testing.T) {
+	in := &GrafanaFolder{ObjectMeta: metav1.ObjectMeta{Name: "@example"}}
+	require.NotNil(t, in.FromResponseBody([]byte(`bad`)))
+	require.NotNil(t, in.FromResponseBody([]byte(`{}`)))
+	require.NoError(t, in.FromResponseBody([]byte(`{"uid":"test","title":"Test"}`)))
+	require.Equal(t, "test@example", in.GetName())
+	require.Equal(t, []byte(`{"uid":"test","title":"Test"}`), in.Spec.Raw)
+}
+
+func TestGrafanaFolderListFromResponseBody(t *testing.T) {
+	in := &GrafanaFolderList{}
+	require.NotNil(t, in.FromResponseBody([]byte(`bad`), "test"))
+	require.Errorf(t, in.FromResponseBody([]byte(`[{}]`), "test"), "invalid grafana folder response, no valid uid found")
+	require.NoError(t, in.FromResponseBody([]byte(`[{"uid":"a","title":"A"},{"uid":"b","title":"B"}]`), "test"))
+	require.Equal(t, 2, len(in.Items))
+	require.Equal(t, "a@test", in.Items[0].GetName())
+	require.Equal(t, []byte(`{"title":"A","uid":"a"}`), in.Items[0].Spec.Raw)
+}
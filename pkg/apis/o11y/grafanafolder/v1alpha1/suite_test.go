@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/utils/pointer"
+
+	"github.com/kubevela/pkg/util/apiserver"
+
+	"github.com/kubevela/prism/pkg/apis/o11y/config"
+	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
+	"github.com/kubevela/prism/pkg/util/subresource"
+	_ "github.com/kubevela/prism/test/bootstrap"
+)
+
+func TestGrafanaFolder(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GrafanaFolder Extension API Test")
+}
+
+var _ = Describe("Test GrafanaFolder API", func() {
+
+	var mockServer *httptest.Server
+	var data map[string][]byte
+	// hidden titles are skipped by the next search, as if created concurrently
+	var hidden map[string]bool
+
+	BeforeEach(func() {
+		Ω(k8s.EnsureNamespace(context.Background(), singleton.KubeClient.Get(), config.ObservabilityNamespace)).To(Succeed())
+		data = map[string][]byte{}
+		hidden = map[string]bool{}
+		mockServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			p := request.Method + " " + request.URL.Path
+			switch {
+			case p == "POST /api/folders":
+				bs, _ := io.ReadAll(request.Body)
+				var m map[string]interface{}
+				_ = json.Unmarshal(bs, &m)
+				for _, val := range data {
+					var folder map[string]interface{}
+					_ = json.Unmarshal(val, &folder)
+					if folder["title"] == m["title"] {
+						writer.WriteHeader(http.StatusConflict)
+						return
+					}
+				}
+				if m["uid"] == nil {
+					m["uid"] = fmt.Sprintf("generated-%d", len(data))
+				}
+				bs, _ = json.Marshal(m)
+				data[m["uid"].(string)] = bs
+				_, _ = writer.Write(bs)
+			case p == "GET /api/folders" || p == "GET /api/search" && request.URL.Query().Get("type") == "dash-folder":
+				var folders []string
+				for _, val := range data {
+					var folder map[string]interface{}
+					_ = json.Unmarshal(val, &folder)
+					if title, _ := folder["title"].(string); hidden[title] && p == "GET /api/search" {
+						delete(hidden, title)
+						continue
+					}
+					folders = append(folders, string(val))
+				}
+				_, _ = writer.Write([]byte("[" + strings.Join(folders, ",") + "]"))
+			case strings.HasPrefix(p, "GET /api/folders/"):
+				uid := strings.TrimPrefix(p, "GET /api/folders/")
+				if folder, ok := data[uid]; ok {
+					_, _ = writer.Write(folder)
+				} else {
+					writer.WriteHeader(http.StatusNotFound)
+				}
+			case strings.HasPrefix(p, "PUT /api/folders/"):
+				uid := strings.TrimPrefix(p, "PUT /api/folders/")
+				bs, _ := io.ReadAll(request.Body)
+				var m map[string]interface{}
+				_ = json.Unmarshal(bs, &m)
+				if _, ok := data[uid]; !ok || m["overwrite"] != true {
+					writer.WriteHeader(http.StatusPreconditionFailed)
+					return
+				}
+				data[uid] = bs
+				_, _ = writer.Write(bs)
+			case strings.HasPrefix(p, "DELETE /api/folders/"):
+				uid := strings.TrimPrefix(p, "DELETE /api/folders/")
+				if _, ok := data[uid]; ok {
+					delete(data, uid)
+				} else {
+					writer.WriteHeader(http.StatusNotFound)
+				}
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		Ω(k8s.ClearNamespace(context.Background(), singleton.KubeClient.Get(), config.ObservabilityNamespace)).To(Succeed())
+		mockServer.Close()
+	})
+
+	It("Test GrafanaFolder API", func() {
+		s := &GrafanaFolder{}
+		By("Test meta info")
+		Ω(s.New()).To(Equal(&GrafanaFolder{}))
+		Ω(s.NamespaceScoped()).To(BeFalse())
+		Ω(s.ShortNames()).To(ContainElement("gfd"))
+		Ω(s.GetGroupVersionResource().Resource).To(Equal(GrafanaFolderResource))
+		Ω(s.NewList()).To(Equal(&GrafanaFolderList{}))
+
+		ctx := context.Background()
+
+		By("Create Grafana")
+		grafana := &grafanav1alpha1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: subresource.DefaultParentResourceName},
+			Spec: grafanav1alpha1.GrafanaSpec{
+				Endpoint: mockServer.URL,
+				Access:   grafanav1alpha1.AccessCredential{Token: pointer.String("mock")},
+			},
+		}
+		_, err := (&grafanav1alpha1.Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+
+		By("Test Create GrafanaFolder")
+		for _, uid := range []string{"alpha", "beta"} {
+			_, err = s.Create(ctx, &GrafanaFolder{
+				ObjectMeta: metav1.ObjectMeta{Name: uid},
+				Spec:       runtime.RawExtension{Raw: []byte(`{"title":"` + uid + `"}`)},
+			}, nil, nil)
+			Ω(err).To(Succeed())
+		}
+
+		By("Test Update GrafanaFolder")
+		_, _, err = s.Update(ctx, "beta", rest.DefaultUpdatedObjectInfo(&GrafanaFolder{
+			ObjectMeta: metav1.ObjectMeta{Name: "beta"},
+			Spec:       runtime.RawExtension{Raw: []byte(`{"title":"Beta"}`)},
+		}), nil, nil, false, nil)
+		Ω(err).To(Succeed())
+
+		By("Test Get GrafanaFolder")
+		obj, err := s.Get(ctx, "beta", nil)
+		Ω(err).To(Succeed())
+		Ω(apiserver.GetStringFromRawExtension(&obj.(*GrafanaFolder).Spec, "title")).To(Equal("Beta"))
+
+		By("Test Ensure GrafanaFolder")
+		cli := NewGrafanaFolderClient(singleton.KubeClient.Get())
+		folder, err := cli.Ensure(ctx, subresource.DefaultParentResourceName, 0, "Gamma")
+		Ω(err).To(Succeed())
+		Ω(apiserver.GetStringFromRawExtension(&folder.Spec, "title")).To(Equal("Gamma"))
+		hidden["Gamma"] = true
+		existing, err := cli.Ensure(ctx, subresource.DefaultParentResourceName, 0, "Gamma")
+		Ω(err).To(Succeed())
+		Ω(existing.GetName()).To(Equal(folder.GetName()))
+		_, err = s.Create(ctx, &GrafanaFolder{
+			ObjectMeta: metav1.ObjectMeta{Name: "gamma"},
+			Spec:       runtime.RawExtension{Raw: []byte(`{"title":"Gamma"}`)},
+		}, nil, nil)
+		Ω(err).To(Satisfy(apierrors.IsConflict))
+
+		By("Test List GrafanaFolder")
+		objs, err := s.List(ctx, nil)
+		Ω(err).To(Succeed())
+		Ω(len(objs.(*GrafanaFolderList).Items)).To(Equal(3))
+
+		By("Test Delete GrafanaFolder")
+		_, _, err = s.Delete(ctx, "alpha", nil, nil)
+		Ω(err).To(Succeed())
+		objs, err = s.List(ctx, nil)
+		Ω(err).To(Succeed())
+		Ω(len(objs.(*GrafanaFolderList).Items)).To(Equal(2))
+
+		By("Test GrafanaFolder Printer")
+		_, err = s.ConvertToTable(ctx, obj, nil)
+		Ω(err).To(Succeed())
+		_, err = s.ConvertToTable(ctx, objs, nil)
+		Ω(err).To(Succeed())
+	})
+
+})
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/util/apiserver"
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// GrafanaFolder is a reflection api for Grafana Folder
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type GrafanaFolder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec,omitempty"`
+}
+
+// GrafanaFolderList list for GrafanaFolder
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type GrafanaFolderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GrafanaFolder `json:"items"`
+}
+
+var _ resource.Object = &GrafanaFolder{}
+var _ rest.Getter = &GrafanaFolder{}
+var _ rest.CreaterUpdater = &GrafanaFolder{}
+var _ rest.Patcher = &GrafanaFolder{}
+var _ rest.GracefulDeleter = &GrafanaFolder{}
+var _ rest.Lister = &GrafanaFolder{}
+
+// GetObjectMeta returns the object meta reference.
+func (in *GrafanaFolder) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// NamespaceScoped returns if the object must be in a namespace.
+func (in *GrafanaFolder) NamespaceScoped() bool {
+	return false
+}
+
+// New returns a new instance of the resource
+func (in *GrafanaFolder) New() runtime.Object {
+	return &GrafanaFolder{}
+}
+
+// Destroy .
+func (in *GrafanaFolder) Destroy() {}
+
+// NewList return a new list instance of the resource
+func (in *GrafanaFolder) NewList() runtime.Object {
+	return &GrafanaFolderList{}
+}
+
+// GetGroupVersionResource returns the GroupVersionResource for this resource.
+func (in *GrafanaFolder) GetGroupVersionResource() schema.GroupVersionResource {
+	return GroupVersion.WithResource(GrafanaFolderResource)
+}
+
+// IsStorageVersion returns true if the object is also the internal version
+func (in *GrafanaFolder) IsStorageVersion() bool {
+	return true
+}
+
+// ShortNames delivers a list of short names for a resource.
+func (in *GrafanaFolder) ShortNames() []string {
+	return []string{"gfd", "grafana-folder", "grafana-folders"}
+}
+
+// Get finds a resource in the storage by name and returns it.
+func (in *GrafanaFolder) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return NewGrafanaFolderClient(singleton.KubeClient.Get()).Get(ctx, name)
+}
+
+func (in *GrafanaFolder) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	return obj, NewGrafanaFolderClient(singleton.KubeClient.Get()).Create(ctx, obj.(*GrafanaFolder))
+}
+
+func (in *GrafanaFolder) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (obj runtime.Object, _ bool, err error) {
+	cli := NewGrafanaFolderClient(singleton.KubeClient.Get())
+	if obj, err = cli.Get(ctx, name); err != nil {
+		return nil, false, err
+	}
+	if obj, err = objInfo.UpdatedObject(ctx, obj); err != nil {
+		return nil, false, err
+	}
+	return obj, false, cli.Update(ctx, obj.(*GrafanaFolder))
+}
+
+func (in *GrafanaFolder) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (obj runtime.Object, _ bool, err error) {
+	cli := NewGrafanaFolderClient(singleton.KubeClient.Get())
+	if obj, err = cli.Get(ctx, name); err != nil {
+		return nil, false, err
+	}
+	return obj, true, cli.Delete(ctx, obj.(*GrafanaFolder))
+}
+
+func (in *GrafanaFolder) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	if name := apiserver.GetMetadataNameInFieldSelectorFromInternalVersionListOptions(options); name != nil {
+		return NewGrafanaFolderClient(singleton.KubeClient.Get()).Get(ctx, *name)
+	}
+	return NewGrafanaFolderClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/apiserver"
+
+	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+// GrafanaOrgClient client for grafana org
+// +kubebuilder:object:generate=false
+type GrafanaOrgClient interface {
+	Get(ctx context.Context, name string) (*GrafanaOrg, error)
+	List(ctx context.Context, options ...client.ListOption) (*GrafanaOrgList, error)
+	Create(ctx context.Context, grafanaOrg *GrafanaOrg) error
+	Delete(ctx context.Context, grafanaOrg *GrafanaOrg) error
+}
+
+// NewGrafanaOrgClient create GrafanaOrgClient
+func NewGrafanaOrgClient(cli client.Client) GrafanaOrgClient {
+	return &grafanaOrgClient{grafanav1alpha1.NewGrafanaClient(cli)}
+}
+
+type grafanaOrgClient struct {
+	grafanav1alpha1.GrafanaClient
+}
+
+func (in *grafanaOrgClient) Get(ctx context.Context, name string) (*GrafanaOrg, error) {
+	resourceName := subresource.NewCompoundName(name)
+	org := &GrafanaOrg{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName.String(), UID: "-"},
+	}
+	return org, grafanav1alpha1.NewGrafanaSubResourceRequest(org, name).
+		WithMethod(http.MethodGet).
+		WithPathFunc(func() (string, error) {
+			return "/api/orgs/name/" + url.PathEscape(resourceName.SubResourceName), nil
+		}).
+		WithOnSuccess(func(respBody []byte) error {
+			org.Spec = runtime.RawExtension{Raw: respBody}
+			return nil
+		}).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaOrgClient) Create(ctx context.Context, org *GrafanaOrg) error {
+	return grafanav1alpha1.NewGrafanaSubResourceRequest(org, org.GetName()).
+		WithMethod(http.MethodPost).
+		WithPathFunc(func() (string, error) {
+			return "/api/orgs", nil
+		}).
+		WithBodyFunc(org.ToRequestBody).
+		WithOnSuccess(org.FromCreateResponseBody).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaOrgClient) Delete(ctx context.Context, org *GrafanaOrg) error {
+	return grafanav1alpha1.NewGrafanaSubResourceRequest(org, org.GetName()).
+		WithMethod(http.MethodDelete).
+		WithPathFunc(func() (string, error) {
+			if org.GetID() == 0 {
+				return "", fmt.Errorf("no valid id found in grafana org %s", org.GetName())
+			}
+			return fmt.Sprintf("/api/orgs/%d", org.GetID()), nil
+		}).
+		Do(ctx, in.GrafanaClient)
+}
+
+func (in *grafanaOrgClient) List(ctx context.Context, options ...client.ListOption) (*GrafanaOrgList, error) {
+	opts := apiserver.NewListOptions(options...)
+	parentResourceName := subresource.GetParentResourceNameFromLabelSelector(opts.LabelSelector, "grafana")
+	orgs := &GrafanaOrgList{}
+	return orgs, grafanav1alpha1.NewGrafanaSubResourceRequest(&grafanav1alpha1.Grafana{}, (&subresource.CompoundName{ParentResourceName: parentResourceName}).String()).
+		WithMethod(http.MethodGet).
+		WithPathFunc(func() (string, error) {
+			return "/api/orgs", nil
+		}).
+		WithOnSuccess(func(respBody []byte) error {
+			return orgs.FromResponseBody(respBody, parentResourceName)
+		}).
+		Do(ctx, in.GrafanaClient)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+// GetID get id from GrafanaOrg, 0 is returned if not found
+func (in *GrafanaOrg) GetID() int64 {
+	obj := struct {
+		ID int64 `json:"id"`
+	}{}
+	_ = json.Unmarshal(in.Spec.Raw, &obj)
+	return obj.ID
+}
+
+// ToRequestBody convert object into body for request
+func (in *GrafanaOrg) ToRequestBody() ([]byte, error) {
+	org := map[string]interface{}{}
+	if len(in.Spec.Raw) > 0 {
+		if err := json.Unmarshal(in.Spec.Raw, &org); err != nil {
+			return nil, err
+		}
+	}
+	org["name"] = subresource.NewCompoundName(in.GetName()).SubResourceName
+	delete(org, "id")
+	return json.Marshal(org)
+}
+
+// FromCreateResponseBody load the id of the created org from grafana api
+// create response
+func (in *GrafanaOrg) FromCreateResponseBody(respBody []byte) error {
+	obj := &struct {
+		OrgID json.Number `json:"orgId"`
+	}{}
+	if err := json.Unmarshal(respBody, obj); err != nil {
+		return err
+	}
+	id, err := obj.OrgID.Int64()
+	if err != nil {
+		return fmt.Errorf("invalid grafana org response, no valid orgId found")
+	}
+	bs, err := in.ToRequestBody()
+	if err != nil {
+		return err
+	}
+	org := map[string]interface{}{}
+	if err = json.Unmarshal(bs, &org); err != nil {
+		return err
+	}
+	org["id"] = id
+	if bs, err = json.Marshal(org); err != nil {
+		return err
+	}
+	in.Spec = runtime.RawExtension{Raw: bs}
+	return nil
+}
+
+// FromResponseBody load orgs from grafana api
+func (in *GrafanaOrgList) FromResponseBody(respBody []byte, parentResourceName string) error {
+	data := []map[string]interface{}{}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return err
+	}
+	in.Items = []GrafanaOrg{}
+	for _, raw := range data {
+		org := &GrafanaOrg{}
+		name, ok := raw["name"].(string)
+		if !ok {
+			return fmt.Errorf("invalid grafana org response, no valid name found")
+		}
+		org.SetName((&subresource.CompoundName{ParentResourceName: parentResourceName, SubResourceName: name}).String())
+		bs, err := json.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		org.Spec = runtime.RawExtension{Raw: bs}
+		in.Items = append(in.Items, *org)
+	}
+	return nil
+}
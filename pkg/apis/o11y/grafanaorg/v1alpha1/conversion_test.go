@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGrafanaOrgToRequestBody(t *testing.T) {
+	in := &GrafanaOrg{ObjectMeta: metav1.ObjectMeta{Name: "test@example"}}
+	bs, err := in.ToRequestBody()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"name":"test"}`), bs)
+	in.Spec = runtime.RawExtension{Raw: []byte(`bad`)}
+	_, err = in.ToRequestBody()
+	require.NotNil(t, err)
+	in.Spec = runtime.RawExtension{Raw: []byte(`{"id":2,"name":"other"}`)}
+	bs, err = in.ToRequestBody()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"name":"test"}`), bs)
+}
+
+func TestGrafanaOrgFromCreateResponseBody(t *testing.T) {
+	in := &GrafanaOrg{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	require.NotNil(t, in.FromCreateResponseBody([]byte(`bad`)))
+	require.NotNil(t, in.FromCreateResponseBody([]byte(`{}`)))
+	require.NoError(t, in.FromCreateResponseBody([]byte(`{"orgId":"2","message":"Organization created"}`)))
+	require.Equal(t, []byte(`{"id":2,"name":"test"}`), in.Spec.Raw)
+	require.NoError(t, in.FromCreateResponseBody([]byte(`{"orgId":3}`)))
+	require.Equal(t, int64(3), in.GetID())
+}
+
+func TestGrafanaOrgListFromResponseBody(t *testing.T) {
+	in := &GrafanaOrgList{}
+	require.NotNil(t, in.FromResponseBody([]byte(`bad`), "test"))
+	require.Errorf(t, in.FromResponseBody([]byte(`[{}]`), "test"), "invalid grafana org response, no valid name found")
+	require.NoError(t, in.FromResponseBody([]byte(`[{"id":1,"name":"Main Org."},{"id":2,"name":"b"}]`), "test"))
+	require.Equal(t, 2, len(in.Items))
+	require.Equal(t, "Main Org.@test", in.Items[0].GetName())
+	require.Equal(t, int64(1), in.Items[0].GetID())
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubevela/prism/pkg/util/subresource"
+)
+
+// ConvertToTable convert resource to table
+func (in *GrafanaOrg) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	switch obj := object.(type) {
+	case *GrafanaOrg:
+		return printGrafanaOrg(obj), nil
+	case *GrafanaOrgList:
+		return printGrafanaOrgList(obj), nil
+	default:
+		return nil, fmt.Errorf("unknown type %T", object)
+	}
+}
+
+var (
+	definitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string", Format: "name", Description: "the name of the GrafanaOrg"},
+		{Name: "ID", Type: "integer", Description: "the id of the GrafanaOrg"},
+	}
+)
+
+func printGrafanaOrg(in *GrafanaOrg) *metav1.Table {
+	return &metav1.Table{
+		ColumnDefinitions: definitions,
+		Rows:              []metav1.TableRow{printGrafanaOrgRow(in)},
+	}
+}
+
+func printGrafanaOrgList(in *GrafanaOrgList) *metav1.Table {
+	t := &metav1.Table{
+		ColumnDefinitions: definitions,
+	}
+	for _, c := range in.Items {
+		t.Rows = append(t.Rows, printGrafanaOrgRow(c.DeepCopy()))
+	}
+	return t
+}
+
+func printGrafanaOrgRow(c *GrafanaOrg) metav1.TableRow {
+	row := metav1.TableRow{
+		Object: runtime.RawExtension{Object: c},
+	}
+	row.Cells = append(row.Cells,
+		subresource.NewCompoundName(c.Name).SubResourceName,
+		c.GetID(),
+	)
+	return row
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubevela/pkg/util/k8s"
+	"github.com/kubevela/pkg/util/singleton"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/kubevela/prism/pkg/apis/o11y/config"
+	grafanav1alpha1 "github.com/kubevela/prism/pkg/apis/o11y/grafana/v1alpha1"
+	"github.com/kubevela/prism/pkg/util/subresource"
+	_ "github.com/kubevela/prism/test/bootstrap"
+)
+
+func TestGrafanaOrg(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GrafanaOrg Extension API Test")
+}
+
+var _ = Describe("Test GrafanaOrg API", func() {
+
+	var mockServer *httptest.Server
+	var orgs map[string]int64
+	var orgHeaders []string
+
+	BeforeEach(func() {
+		Ω(k8s.EnsureNamespace(context.Background(), singleton.KubeClient.Get(), config.ObservabilityNamespace)).To(Succeed())
+		orgs = map[string]int64{"Main Org.": 1}
+		orgHeaders = nil
+		mockServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			orgHeaders = append(orgHeaders, request.Header.Get("X-Grafana-Org-Id"))
+			p := request.Method + " " + request.URL.Path
+			switch {
+			case p == "POST /api/orgs":
+				bs, _ := io.ReadAll(request.Body)
+				org := map[string]interface{}{}
+				_ = json.Unmarshal(bs, &org)
+				orgs[org["name"].(string)] = int64(len(orgs) + 1)
+				_, _ = writer.Write([]byte(fmt.Sprintf(`{"orgId":"%d","message":"Organization created"}`, len(orgs))))
+			case p == "GET /api/orgs":
+				var items []string
+				for name, id := range orgs {
+					items = append(items, fmt.Sprintf(`{"id":%d,"name":%q}`, id, name))
+				}
+				_, _ = writer.Write([]byte("[" + strings.Join(items, ",") + "]"))
+			case strings.HasPrefix(p, "GET /api/orgs/name/"):
+				name := strings.TrimPrefix(p, "GET /api/orgs/name/")
+				if id, ok := orgs[name]; ok {
+					_, _ = writer.Write([]byte(fmt.Sprintf(`{"id":%d,"name":%q}`, id, name)))
+				} else {
+					writer.WriteHeader(http.StatusNotFound)
+				}
+			case strings.HasPrefix(p, "DELETE /api/orgs/"):
+				id := strings.TrimPrefix(p, "DELETE /api/orgs/")
+				for name := range orgs {
+					if fmt.Sprintf("%d", orgs[name]) == id {
+						delete(orgs, name)
+					}
+				}
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		Ω(k8s.ClearNamespace(context.Background(), singleton.KubeClient.Get(), config.ObservabilityNamespace)).To(Succeed())
+		mockServer.Close()
+	})
+
+	It("Test GrafanaOrg API", func() {
+		s := &GrafanaOrg{}
+		By("Test meta info")
+		Ω(s.New()).To(Equal(&GrafanaOrg{}))
+		Ω(s.NamespaceScoped()).To(BeFalse())
+		Ω(s.ShortNames()).To(ContainElement("gorg"))
+		Ω(s.GetGroupVersionResource().Resource).To(Equal(GrafanaOrgResource))
+		Ω(s.NewList()).To(Equal(&GrafanaOrgList{}))
+
+		ctx := context.Background()
+
+		By("Create Grafana")
+		grafana := &grafanav1alpha1.Grafana{
+			ObjectMeta: metav1.ObjectMeta{Name: subresource.DefaultParentResourceName},
+			Spec: grafanav1alpha1.GrafanaSpec{
+				Endpoint: mockServer.URL,
+				Access:   grafanav1alpha1.AccessCredential{Token: pointer.String("mock")},
+				OrgID:    1,
+			},
+		}
+		_, err := (&grafanav1alpha1.Grafana{}).Create(ctx, grafana, nil, nil)
+		Ω(err).To(Succeed())
+
+		By("Test Create GrafanaOrg")
+		obj, err := s.Create(ctx, &GrafanaOrg{ObjectMeta: metav1.ObjectMeta{Name: "alpha"}}, nil, nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*GrafanaOrg).GetID()).To(Equal(int64(2)))
+
+		By("Test Get GrafanaOrg")
+		obj, err = s.Get(ctx, "alpha", nil)
+		Ω(err).To(Succeed())
+		Ω(obj.(*GrafanaOrg).Spec.Raw).To(Equal([]byte(`{"id":2,"name":"alpha"}`)))
+
+		By("Test List GrafanaOrg")
+		objs, err := s.List(ctx, nil)
+		Ω(err).To(Succeed())
+		Ω(len(objs.(*GrafanaOrgList).Items)).To(Equal(2))
+
+		By("Test org header")
+		Ω(orgHeaders).To(HaveEach("1"))
+		Ω(grafanav1alpha1.NewGrafanaSubResourceRequest(s, "alpha").WithOrgID(2).WithMethod(http.MethodGet).
+			WithPathFunc(func() (string, error) { return "/api/orgs", nil }).
+			Do(ctx, grafanav1alpha1.NewGrafanaClient(singleton.KubeClient.Get()))).To(Succeed())
+		Ω(orgHeaders[len(orgHeaders)-1]).To(Equal("2"))
+
+		By("Test Delete GrafanaOrg")
+		_, _, err = s.Delete(ctx, "alpha", nil, nil)
+		Ω(err).To(Succeed())
+		objs, err = s.List(ctx, nil)
+		Ω(err).To(Succeed())
+		Ω(len(objs.(*GrafanaOrgList).Items)).To(Equal(1))
+
+		By("Test GrafanaOrg Printer")
+		_, err = s.ConvertToTable(ctx, obj, nil)
+		Ω(err).To(Succeed())
+		_, err = s.ConvertToTable(ctx, objs, nil)
+		Ω(err).To(Succeed())
+	})
+
+})
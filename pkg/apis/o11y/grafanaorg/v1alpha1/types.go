@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+
+	"github.com/kubevela/pkg/util/apiserver"
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// GrafanaOrg is a reflection api for Grafana Organization
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type GrafanaOrg struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec,omitempty"`
+}
+
+// GrafanaOrgList list for GrafanaOrg
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type GrafanaOrgList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GrafanaOrg `json:"items"`
+}
+
+var _ resource.Object = &GrafanaOrg{}
+var _ rest.Getter = &GrafanaOrg{}
+var _ rest.Creater = &GrafanaOrg{}
+var _ rest.GracefulDeleter = &GrafanaOrg{}
+var _ rest.Lister = &GrafanaOrg{}
+
+// GetObjectMeta returns the object meta reference.
+func (in *GrafanaOrg) GetObjectMeta() *metav1.ObjectMeta {
+	return &in.ObjectMeta
+}
+
+// NamespaceScoped returns if the object must be in a namespace.
+func (in *GrafanaOrg) NamespaceScoped() bool {
+	return false
+}
+
+// New returns a new instance of the resource
+func (in *GrafanaOrg) New() runtime.Object {
+	return &GrafanaOrg{}
+}
+
+// Destroy .
+func (in *GrafanaOrg) Destroy() {}
+
+// NewList return a new list instance of the resource
+func (in *GrafanaOrg) NewList() runtime.Object {
+	return &GrafanaOrgList{}
+}
+
+// GetGroupVersionResource returns the GroupVersionResource for this resource.
+func (in *GrafanaOrg) GetGroupVersionResource() schema.GroupVersionResource {
+	return GroupVersion.WithResource(GrafanaOrgResource)
+}
+
+// IsStorageVersion returns true if the object is also the internal version
+func (in *GrafanaOrg) IsStorageVersion() bool {
+	return true
+}
+
+// ShortNames delivers a list of short names for a resource.
+func (in *GrafanaOrg) ShortNames() []string {
+	return []string{"gorg", "grafana-org", "grafana-orgs"}
+}
+
+// Get finds a resource in the storage by name and returns it.
+func (in *GrafanaOrg) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return NewGrafanaOrgClient(singleton.KubeClient.Get()).Get(ctx, name)
+}
+
+func (in *GrafanaOrg) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	return obj, NewGrafanaOrgClient(singleton.KubeClient.Get()).Create(ctx, obj.(*GrafanaOrg))
+}
+
+func (in *GrafanaOrg) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (obj runtime.Object, _ bool, err error) {
+	cli := NewGrafanaOrgClient(singleton.KubeClient.Get())
+	if obj, err = cli.Get(ctx, name); err != nil {
+		return nil, false, err
+	}
+	return obj, true, cli.Delete(ctx, obj.(*GrafanaOrg))
+}
+
+func (in *GrafanaOrg) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	if name := apiserver.GetMetadataNameInFieldSelectorFromInternalVersionListOptions(options); name != nil {
+		return NewGrafanaOrgClient(singleton.KubeClient.Get()).Get(ctx, *name)
+	}
+	return NewGrafanaOrgClient(singleton.KubeClient.Get()).List(ctx, apiserver.NewMatchingLabelSelectorFromInternalVersionListOptions(options))
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// NameSuffixFunc returns the suffix appended to the name of the delegated
+// object to get the name of the underlying cluster-scoped object
+type NameSuffixFunc func(namespace string) string
+
+// DefaultNameSuffix appends the namespace to the name, i.e. <name>-<namespace>
+func DefaultNameSuffix(namespace string) string {
+	return "-" + namespace
+}
+
+// Config the config for delegating cluster-scoped objects into a
+// namespace-scoped view
+type Config struct {
+	// GroupResource is the resource of the namespace-scoped view
+	GroupResource schema.GroupResource
+	// GroupVersionKind is the kind of the namespace-scoped view
+	GroupVersionKind schema.GroupVersionKind
+	// DelegateGroupVersionKind is the kind of the underlying cluster-scoped object
+	DelegateGroupVersionKind schema.GroupVersionKind
+	// NamespaceLabelKey is the label on the underlying object that records the
+	// namespace of the view. Objects without the label are in the default namespace.
+	NamespaceLabelKey string
+	// NameSuffix is the name-suffix convention of the underlying objects,
+	// DefaultNameSuffix is used if not set
+	NameSuffix NameSuffixFunc
+
+	// NewFunc returns a new instance of the view
+	NewFunc func() runtime.Object
+	// NewListFunc returns a new list instance of the view
+	NewListFunc func() runtime.Object
+	// FieldsFunc returns the extra fields of the view for field selectors,
+	// metadata.name and metadata.namespace are always supported
+	FieldsFunc func(obj runtime.Object) fields.Set
+}
+
+// Storage is a namespace-scoped read-only storage backed by cluster-scoped objects
+// +kubebuilder:object:generate=false
+type Storage struct {
+	Config
+}
+
+var _ rest.Storage = &Storage{}
+var _ rest.Scoper = &Storage{}
+var _ rest.Getter = &Storage{}
+var _ rest.Lister = &Storage{}
+var _ rest.Watcher = &Storage{}
+
+// NewStorage create a delegated storage with the config
+func NewStorage(cfg Config) *Storage {
+	if cfg.NameSuffix == nil {
+		cfg.NameSuffix = DefaultNameSuffix
+	}
+	return &Storage{Config: cfg}
+}
+
+// New returns a new instance of the view
+func (in *Storage) New() runtime.Object {
+	return in.NewFunc()
+}
+
+// NewList returns a new list instance of the view
+func (in *Storage) NewList() runtime.Object {
+	return in.NewListFunc()
+}
+
+// Destroy .
+func (in *Storage) Destroy() {}
+
+// NamespaceScoped returns true as the view is always namespace-scoped
+func (in *Storage) NamespaceScoped() bool {
+	return true
+}
+
+// ConvertToTable convert resource to table
+func (in *Storage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(in.GroupResource).ConvertToTable(ctx, object, tableOptions)
+}
+
+// Get finds the underlying object by the name and the namespace of the request
+// and returns its view.
+func (in *Storage) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	ns := request.NamespaceValue(ctx)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(in.DelegateGroupVersionKind)
+	if err := singleton.KubeClient.Get().Get(ctx, types.NamespacedName{Name: name + in.NameSuffix(ns)}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(in.GroupResource, name)
+		}
+		return nil, err
+	}
+	view, err := in.Convert(obj)
+	if err != nil {
+		return nil, err
+	}
+	// objects without the namespace label only belong to the default namespace
+	if ns != "" && view.GetNamespace() != ns {
+		return nil, apierrors.NewNotFound(in.GroupResource, name)
+	}
+	return view, nil
+}
+
+// List lists the underlying objects in the namespace of the request and
+// returns their views. Field selectors are matched against the views, and
+// pagination is forwarded to the underlying list. If listing across all
+// namespaces, only the views in the namespaces that the caller is allowed to
+// list are returned. 'options' can be nil.
+func (in *Storage) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	m, err := in.newMatcher(ctx, options, true)
+	if err != nil {
+		return nil, err
+	}
+	objs := &unstructured.UnstructuredList{}
+	objs.SetGroupVersionKind(in.DelegateGroupVersionKind.GroupVersion().WithKind(in.DelegateGroupVersionKind.Kind + "List"))
+	if err = singleton.KubeClient.Get().List(ctx, objs, in.newListOptions(ctx, options)...); err != nil {
+		return nil, err
+	}
+	list := in.NewList()
+	var items []runtime.Object
+	for _, obj := range objs.Items {
+		view, err := in.Convert(obj.DeepCopy())
+		if err != nil {
+			return nil, err
+		}
+		if m.Matches(view) {
+			items = append(items, view)
+		}
+	}
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, err
+	}
+	listMeta.SetResourceVersion(objs.GetResourceVersion())
+	listMeta.SetContinue(objs.GetContinue())
+	return list, nil
+}
+
+// Convert converts the underlying object into the view. The namespace of the
+// view is recorded in the namespace label and the name suffix is trimmed.
+func (in *Storage) Convert(obj *unstructured.Unstructured) (client.Object, error) {
+	view, ok := in.New().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", view)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, view); err != nil {
+		return nil, err
+	}
+	ns := in.GetNamespace(obj)
+	view.SetNamespace(ns)
+	view.SetName(strings.TrimSuffix(obj.GetName(), in.NameSuffix(ns)))
+	view.GetObjectKind().SetGroupVersionKind(in.GroupVersionKind)
+	return view, nil
+}
+
+// GetNamespace returns the namespace of the view for the underlying object
+func (in *Storage) GetNamespace(obj client.Object) string {
+	if ns := obj.GetLabels()[in.NamespaceLabelKey]; ns != "" {
+		return ns
+	}
+	return metav1.NamespaceDefault
+}
+
+func (in *Storage) newListOptions(ctx context.Context, options *metainternalversion.ListOptions) []client.ListOption {
+	sel := labels.NewSelector()
+	// limit and continue in raw are overridden by the outer ones
+	listOpts := &client.ListOptions{Raw: &metav1.ListOptions{}}
+	raw := listOpts.Raw
+	if options != nil {
+		if options.LabelSelector != nil {
+			sel = options.LabelSelector.DeepCopySelector()
+		}
+		listOpts.Limit = options.Limit
+		listOpts.Continue = options.Continue
+		raw.ResourceVersion = options.ResourceVersion
+		raw.ResourceVersionMatch = options.ResourceVersionMatch
+		raw.AllowWatchBookmarks = options.AllowWatchBookmarks
+		raw.TimeoutSeconds = options.TimeoutSeconds
+	}
+	// objects without the namespace label are in the default namespace, so the
+	// default namespace can only be filtered after conversion
+	ns := request.NamespaceValue(ctx)
+	if ns != "" && ns != metav1.NamespaceDefault {
+		r, _ := labels.SelectorFromValidatedSet(map[string]string{in.NamespaceLabelKey: ns}).Requirements()
+		sel = sel.Add(r...)
+	}
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: sel}, listOpts}
+	// the name of the underlying object is only known when the namespace is set
+	if options != nil && options.FieldSelector != nil && ns != "" {
+		if name, found := options.FieldSelector.RequiresExactMatch("metadata.name"); found {
+			opts = append(opts, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("metadata.name", name+in.NameSuffix(ns))})
+		}
+	}
+	return opts
+}
+
+// GetFields returns the fields of the view for matching field selectors
+func (in *Storage) GetFields(obj runtime.Object) fields.Set {
+	set := fields.Set{}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		set["metadata.name"] = accessor.GetName()
+		set["metadata.namespace"] = accessor.GetNamespace()
+	}
+	if in.FieldsFunc != nil {
+		for k, v := range in.FieldsFunc(obj) {
+			set[k] = v
+		}
+	}
+	return set
+}
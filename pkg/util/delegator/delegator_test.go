@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+func newTestStorage() *Storage {
+	// delegate cluster-scoped namespaces into namespace-scoped configmaps
+	return NewStorage(Config{
+		GroupResource:            corev1.Resource("configmaps"),
+		GroupVersionKind:         corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		DelegateGroupVersionKind: corev1.SchemeGroupVersion.WithKind("Namespace"),
+		NamespaceLabelKey:        "ns",
+		NewFunc:                  func() runtime.Object { return &corev1.ConfigMap{} },
+		NewListFunc:              func() runtime.Object { return &corev1.ConfigMapList{} },
+	})
+}
+
+func newTestObject(name string, ns string, labels map[string]string) client.Object {
+	obj := &corev1.Namespace{}
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	if ns != "" {
+		obj.Labels["ns"] = ns
+	}
+	return obj
+}
+
+// userNamespace the namespace that non-admin users can access
+var userNamespace atomic.Value
+
+// setTestAuthorizer allows admin to access all namespaces and others to access
+// the user namespace, which is example by default. The loopback authorizer can
+// only be set once.
+func setTestAuthorizer() {
+	userNamespace.Store("example")
+	loopback.SetAuthorizer(authorizer.AuthorizerFunc(func(ctx context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		if a.GetUser().GetName() == "admin" || a.GetNamespace() == userNamespace.Load() {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionDeny, "", nil
+	}))
+}
+
+func TestStorage(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newTestObject("app-1-example", "example", map[string]string{"key": "x"}),
+		newTestObject("app-2-example", "example", map[string]string{"key": "y"}),
+		newTestObject("app-1-default", "", map[string]string{"key": "x"}),
+		newTestObject("app-4-other", "", map[string]string{}),
+	).WithIndex(&corev1.Namespace{}, "metadata.name", func(obj client.Object) []string {
+		return []string{obj.GetName()}
+	}).Build()
+	singleton.KubeClient.Set(cli)
+	setTestAuthorizer()
+	s := newTestStorage()
+	ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin"})
+	require.True(t, s.NamespaceScoped())
+	require.Equal(t, &corev1.ConfigMap{}, s.New())
+	require.Equal(t, &corev1.ConfigMapList{}, s.NewList())
+
+	obj, err := s.Get(request.WithNamespace(ctx, "example"), "app-1", nil)
+	require.NoError(t, err)
+	cm := obj.(*corev1.ConfigMap)
+	require.Equal(t, "app-1", cm.GetName())
+	require.Equal(t, "example", cm.GetNamespace())
+	require.Equal(t, "ConfigMap", cm.Kind)
+
+	obj, err = s.Get(request.WithNamespace(ctx, "default"), "app-1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "default", obj.(*corev1.ConfigMap).GetNamespace())
+
+	_, err = s.Get(request.WithNamespace(ctx, "example"), "app-3", nil)
+	require.True(t, apierrors.IsNotFound(err))
+
+	// objects without the namespace label belong to the default namespace
+	_, err = s.Get(request.WithNamespace(ctx, "other"), "app-4", nil)
+	require.True(t, apierrors.IsNotFound(err))
+	obj, err = s.List(request.WithNamespace(ctx, "default"), nil)
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 2)
+
+	obj, err = s.List(request.WithNamespace(ctx, "example"), nil)
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 2)
+
+	obj, err = s.List(ctx, &metainternalversion.ListOptions{LabelSelector: labels.SelectorFromSet(map[string]string{"key": "x"})})
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 2)
+
+	obj, err = s.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", "app-1")})
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 2)
+
+	obj, err = s.List(request.WithNamespace(ctx, "example"), &metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", "app-1")})
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 1)
+
+	obj, err = s.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.OneTermNotEqualSelector("metadata.namespace", "default")})
+	require.NoError(t, err)
+	require.Len(t, obj.(*corev1.ConfigMapList).Items, 2)
+
+	w, err := s.NewWatcher(request.WithNamespace(ctx, "example"), cli, nil)
+	require.NoError(t, err)
+	defer w.Stop()
+	require.NoError(t, cli.Create(ctx, newTestObject("app-3-example", "example", map[string]string{})))
+	event := <-w.ResultChan()
+	require.Equal(t, watch.Added, event.Type)
+	require.Equal(t, "app-3", event.Object.(*corev1.ConfigMap).GetName())
+}
+
+func TestStorageAuthorization(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newTestObject("app-1-example", "example", map[string]string{}),
+		newTestObject("app-2-example", "example", map[string]string{}),
+		newTestObject("app-1-default", "", map[string]string{}),
+	).Build()
+	singleton.KubeClient.Set(cli)
+	setTestAuthorizer()
+	s := newTestStorage()
+	list := func(ctx context.Context) []corev1.ConfigMap {
+		obj, err := s.List(ctx, nil)
+		require.NoError(t, err)
+		return obj.(*corev1.ConfigMapList).Items
+	}
+	ctx := context.Background()
+	_, err := s.List(ctx, nil)
+	require.True(t, apierrors.IsForbidden(err))
+	_, err = s.NewWatcher(ctx, cli, nil)
+	require.True(t, apierrors.IsForbidden(err))
+	require.Len(t, list(request.WithUser(ctx, &user.DefaultInfo{Name: "admin"})), 3)
+	require.Len(t, list(request.WithUser(ctx, &user.DefaultInfo{Name: "example"})), 2)
+	require.Len(t, list(request.WithUser(request.WithNamespace(ctx, "default"), &user.DefaultInfo{Name: "example"})), 1)
+
+	// the access is checked on each event of the watch across all namespaces
+	w, err := s.NewWatcher(request.WithUser(ctx, &user.DefaultInfo{Name: "example"}), cli, nil)
+	require.NoError(t, err)
+	defer w.Stop()
+	require.NoError(t, cli.Create(ctx, newTestObject("app-3-example", "example", map[string]string{})))
+	event := <-w.ResultChan()
+	require.Equal(t, "app-3", event.Object.(*corev1.ConfigMap).GetName())
+	userNamespace.Store("default")
+	defer userNamespace.Store("example")
+	require.NoError(t, cli.Create(ctx, newTestObject("app-4-example", "example", map[string]string{})))
+	require.NoError(t, cli.Create(ctx, newTestObject("app-5-default", "", map[string]string{})))
+	event = <-w.ResultChan()
+	require.Equal(t, "app-5", event.Object.(*corev1.ConfigMap).GetName())
+	require.Equal(t, "default", event.Object.(*corev1.ConfigMap).GetNamespace())
+}
+
+func TestStorageListOptions(t *testing.T) {
+	s := newTestStorage()
+	opts := (&client.ListOptions{}).ApplyOptions(s.newListOptions(request.WithNamespace(context.Background(), "example"), &metainternalversion.ListOptions{
+		Limit:           2,
+		Continue:        "token",
+		ResourceVersion: "5",
+	})).AsListOptions()
+	require.Equal(t, int64(2), opts.Limit)
+	require.Equal(t, "token", opts.Continue)
+	require.Equal(t, "5", opts.ResourceVersion)
+	require.Equal(t, "ns=example", opts.LabelSelector)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/apiserver-runtime/pkg/util/loopback"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// matcher filters the views by the namespace and field selector of the
+// request. For requests across all namespaces, the views are also filtered
+// by whether the caller is allowed to access the namespace. The decisions of
+// authorization are cached only if cacheDecisions is set, as a watch could
+// outlive the permission of the caller.
+type matcher struct {
+	storage       *Storage
+	namespace     string
+	fieldSelector fields.Selector
+	authorizer    *namespaceAuthorizer
+}
+
+func (in *Storage) newMatcher(ctx context.Context, options *metainternalversion.ListOptions, cacheDecisions bool) (*matcher, error) {
+	m := &matcher{storage: in, namespace: request.NamespaceValue(ctx)}
+	if options != nil && options.FieldSelector != nil && !options.FieldSelector.Empty() {
+		m.fieldSelector = options.FieldSelector
+	}
+	if m.namespace == "" {
+		authz, err := in.newNamespaceAuthorizer(ctx, cacheDecisions)
+		if err != nil {
+			return nil, err
+		}
+		m.authorizer = authz
+	}
+	return m, nil
+}
+
+// Matches checks if the view should be returned
+func (m *matcher) Matches(obj client.Object) bool {
+	if m.namespace != "" && obj.GetNamespace() != m.namespace {
+		return false
+	}
+	if m.fieldSelector != nil && !m.fieldSelector.Matches(m.storage.GetFields(obj)) {
+		return false
+	}
+	return m.authorizer.Allowed(obj.GetNamespace())
+}
+
+// namespaceAuthorizer checks the access of the caller to the views in each
+// namespace through the loopback authorizer. If decisions is not nil, they are
+// cached for the lifetime of the request.
+type namespaceAuthorizer struct {
+	ctx        context.Context
+	attributes authorizer.AttributesRecord
+	authorizer authorizer.Authorizer
+
+	mu        sync.Mutex
+	decisions map[string]bool
+}
+
+// newNamespaceAuthorizer returns the forbidden error if the request is not made
+// by a user or the loopback authorizer is not exposed, as the access of the
+// caller cannot be checked
+func (in *Storage) newNamespaceAuthorizer(ctx context.Context, cacheDecisions bool) (*namespaceAuthorizer, error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewForbidden(in.GroupResource, "", fmt.Errorf("no user found in request"))
+	}
+	authz := loopback.GetAuthorizer()
+	if authz == nil {
+		return nil, apierrors.NewForbidden(in.GroupResource, "", fmt.Errorf("authorizer is not available"))
+	}
+	verb := "list"
+	if info, found := request.RequestInfoFrom(ctx); found && info.Verb != "" {
+		verb = info.Verb
+	}
+	a := &namespaceAuthorizer{
+		ctx: ctx,
+		attributes: authorizer.AttributesRecord{
+			User:            user,
+			Verb:            verb,
+			APIGroup:        in.GroupResource.Group,
+			APIVersion:      in.GroupVersionKind.Version,
+			Resource:        in.GroupResource.Resource,
+			ResourceRequest: true,
+		},
+		authorizer: authz,
+	}
+	if cacheDecisions {
+		a.decisions = map[string]bool{}
+	}
+	return a, nil
+}
+
+// Allowed checks if the caller can access the views in the namespace
+func (a *namespaceAuthorizer) Allowed(namespace string) bool {
+	if a == nil {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if allowed, found := a.decisions[namespace]; found {
+		return allowed
+	}
+	attributes := a.attributes
+	attributes.Namespace = namespace
+	decision, _, err := a.authorizer.Authorize(a.ctx, attributes)
+	if err != nil {
+		klog.Warningf("failed to authorize %s %s in namespace %s: %v", attributes.Verb, attributes.Resource, namespace, err)
+	}
+	allowed := err == nil && decision == authorizer.DecisionAllow
+	if a.decisions != nil {
+		a.decisions[namespace] = allowed
+	}
+	return allowed
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delegator
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevela/pkg/util/singleton"
+)
+
+// Watch makes a watch on the underlying objects in the namespace of the
+// request. Events are converted into the view and filtered in the same way
+// as List.
+func (in *Storage) Watch(ctx context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	cli, err := client.NewWithWatch(singleton.KubeConfig.Get(), client.Options{Mapper: singleton.RESTMapper.Get()})
+	if err != nil {
+		return nil, err
+	}
+	return in.NewWatcher(ctx, cli, options)
+}
+
+// NewWatcher create a watcher with the given client for the underlying
+// objects in the namespace of the request. For watches across all namespaces,
+// the access of the caller is checked again on each event, so that events in
+// namespaces the caller has lost access to are no longer sent.
+func (in *Storage) NewWatcher(ctx context.Context, cli client.WithWatch, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	objs := &unstructured.UnstructuredList{}
+	objs.SetGroupVersionKind(in.DelegateGroupVersionKind.GroupVersion().WithKind(in.DelegateGroupVersionKind.Kind + "List"))
+	m, err := in.newMatcher(ctx, options, false)
+	if err != nil {
+		return nil, err
+	}
+	w, err := cli.Watch(ctx, objs, in.newListOptions(ctx, options)...)
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		event = in.convertEvent(event)
+		if event.Type == watch.Error || event.Type == watch.Bookmark {
+			return event, true
+		}
+		view, ok := event.Object.(client.Object)
+		return event, ok && m.Matches(view)
+	}), nil
+}
+
+func (in *Storage) convertEvent(event watch.Event) watch.Event {
+	if event.Type == watch.Error {
+		return event
+	}
+	obj, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		// typed objects are returned if the delegate kind is registered in the scheme
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Object)
+		if err != nil {
+			status := apierrors.NewInternalError(err).Status()
+			return watch.Event{Type: watch.Error, Object: &status}
+		}
+		obj = &unstructured.Unstructured{Object: m}
+	}
+	if event.Type == watch.Bookmark {
+		// bookmarks only carry the resource version
+		view, ok := in.New().(client.Object)
+		if !ok {
+			return event
+		}
+		view.GetObjectKind().SetGroupVersionKind(in.GroupVersionKind)
+		view.SetResourceVersion(obj.GetResourceVersion())
+		event.Object = view
+		return event
+	}
+	view, err := in.Convert(obj)
+	if err != nil {
+		status := apierrors.NewInternalError(err).Status()
+		return watch.Event{Type: watch.Error, Object: &status}
+	}
+	event.Object = view
+	return event
+}
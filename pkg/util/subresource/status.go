@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subresource
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/apiserver-runtime/pkg/builder/resource"
+)
+
+// StatusSubResourceName the name of the status subresource
+const StatusSubResourceName = "status"
+
+// StatusResource wraps the parent resource so that its status subresource can
+// be registered through builder.APIServer.WithResourceAndHandler. The status
+// subresource registered by apiserver-runtime itself requires an etcd backed
+// parent storage, which is not the case for the resources served by prism.
+type StatusResource struct {
+	resource.Object
+}
+
+// NewStatusResource create the StatusResource for the parent resource
+func NewStatusResource(parent resource.Object) *StatusResource {
+	return &StatusResource{Object: parent}
+}
+
+// GetGroupVersionResource returns the GroupVersionResource of the status subresource
+func (in *StatusResource) GetGroupVersionResource() schema.GroupVersionResource {
+	gvr := in.Object.GetGroupVersionResource()
+	gvr.Resource += "/" + StatusSubResourceName
+	return gvr
+}
@@ -20,7 +20,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 )
 
@@ -37,3 +40,31 @@ func TestGetParentResourceNameFromLabelSelector(t *testing.T) {
 	sel = sel.Add(*r)
 	require.Equal(t, "val", GetParentResourceNameFromLabelSelector(sel, "key"))
 }
+
+type testObject struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+}
+
+func (in *testObject) DeepCopyObject() runtime.Object { return in }
+
+func (in *testObject) GetObjectMeta() *metav1.ObjectMeta { return &in.ObjectMeta }
+
+func (in *testObject) NamespaceScoped() bool { return false }
+
+func (in *testObject) New() runtime.Object { return &testObject{} }
+
+func (in *testObject) NewList() runtime.Object { return &testObject{} }
+
+func (in *testObject) GetGroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: "test.oam.dev", Version: "v1", Resource: "tests"}
+}
+
+func (in *testObject) IsStorageVersion() bool { return true }
+
+func TestStatusResource(t *testing.T) {
+	obj := NewStatusResource(&testObject{})
+	require.Equal(t, schema.GroupVersionResource{Group: "test.oam.dev", Version: "v1", Resource: "tests/status"}, obj.GetGroupVersionResource())
+	require.False(t, obj.NamespaceScoped())
+	require.True(t, obj.IsStorageVersion())
+}